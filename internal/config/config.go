@@ -28,6 +28,15 @@ type D4SConfig struct {
 
 	Logger   LoggerConfig   `yaml:"logger"`
 	ShellPod ShellPodConfig `yaml:"shellPod"`
+	NetTools NetToolsConfig `yaml:"netTools"`
+	Scanner  ScannerConfig  `yaml:"scanner"`
+	SBOM     SBOMConfig     `yaml:"sbom"`
+
+	// Daemon socket path mounted into the scanner and syft containers,
+	// empty to derive it from the context endpoint
+	DockerSocket string `yaml:"dockerSocket"`
+
+	ImageUpdates ImageUpdatesConfig `yaml:"imageUpdates"`
 }
 
 type UIConfig struct {
@@ -51,6 +60,32 @@ type ShellPodConfig struct {
 	Image string `yaml:"image"`
 }
 
+// NetToolsConfig is the helper image joining a container's network namespace
+// for diagnostics and packet captures (it needs sh, dig, curl and tcpdump).
+type NetToolsConfig struct {
+	Image string `yaml:"image"`
+}
+
+// ScannerConfig selects the image vulnerability scanner.
+type ScannerConfig struct {
+	Tool  string `yaml:"tool"`  // trivy or grype
+	Mode  string `yaml:"mode"`  // auto, binary or container
+	Image string `yaml:"image"` // Scanner image in container mode, empty for the official one
+}
+
+// SBOMConfig selects how syft runs for images without an SBOM attestation.
+type SBOMConfig struct {
+	Mode  string `yaml:"mode"`  // auto, binary or container
+	Image string `yaml:"image"` // Syft image in container mode, empty for the official one
+}
+
+// ImageUpdatesConfig controls the registry checks marking containers whose
+// image tag has a newer version.
+type ImageUpdatesConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	Interval string `yaml:"interval"` // How long a tag check is cached, e.g. "30m"
+}
+
 // GetAPIServerTimeout parses the apiServerTimeout string into a time.Duration.
 func (c *D4SConfig) GetAPIServerTimeout() time.Duration {
 	if c.APIServerTimeout == "" {
@@ -72,6 +107,19 @@ func (c *D4SConfig) GetRefreshInterval() time.Duration {
 	return time.Duration(rate * float64(time.Second))
 }
 
+// GetInterval returns how long an image update check is cached, enforcing a
+// 5m minimum since registries rate-limit manifest requests.
+func (c *ImageUpdatesConfig) GetInterval() time.Duration {
+	d, err := time.ParseDuration(c.Interval)
+	if err != nil || c.Interval == "" {
+		return 30 * time.Minute
+	}
+	if d < 5*time.Minute {
+		return 5 * time.Minute
+	}
+	return d
+}
+
 // GetLogSince returns the "since" parameter for log streaming.
 // A value of -1 means tail (no since filter), 0 or positive is seconds.
 func (c *LoggerConfig) GetLogSince() string {
@@ -135,6 +183,20 @@ func DefaultConfig() *Config {
 			ShellPod: ShellPodConfig{
 				Image: "ghcr.io/jr-k/nget:latest",
 			},
+			NetTools: NetToolsConfig{
+				Image: "nicolaka/netshoot:latest",
+			},
+			Scanner: ScannerConfig{
+				Tool: "trivy",
+				Mode: "auto",
+			},
+			SBOM: SBOMConfig{
+				Mode: "auto",
+			},
+			ImageUpdates: ImageUpdatesConfig{
+				Enabled:  false,
+				Interval: "30m",
+			},
 		},
 	}
 }
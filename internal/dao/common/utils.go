@@ -17,6 +17,16 @@ func ShortenPath(path string) string {
 	return path
 }
 
+// ExpandPath resolves a leading "~/" to the user home directory
+func ExpandPath(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return home + path[1:]
+		}
+	}
+	return path
+}
+
 func ParseStatus(s string) (status, age string) {
 	s = strings.TrimSpace(s)
 	
@@ -142,6 +152,76 @@ func FormatBytesFixed(b int64) string {
 	return fmt.Sprintf("%3.0f %s", val, units[exp])
 }
 
+// ShellQuote single-quotes an argument when it contains shell special characters.
+func ShellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	if !strings.ContainsAny(s, " \t\n\"'`$\\|&;<>()*?[]{}!#~") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// JoinCommandLine renders arguments as a command line, quoted when needed
+// (the reverse of SplitCommandLine).
+func JoinCommandLine(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = ShellQuote(a)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// SplitCommandLine splits a command line into arguments, honoring single/double
+// quotes and backslash escapes (no variable expansion).
+func SplitCommandLine(s string) ([]string, error) {
+	var args []string
+	var cur strings.Builder
+	inArg := false
+	var quote rune
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else if r == '\\' && quote == '"' && i+1 < len(runes) {
+				i++
+				cur.WriteRune(runes[i])
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			cur.WriteRune(runes[i])
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in %q", s)
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
+
 func CalculateContainerStats(body io.ReadCloser) (float64, uint64, uint64) {
 	defer body.Close()
 	var v map[string]interface{}
@@ -96,13 +96,25 @@ type ComposeProject struct {
 	Ready       string
 	ConfigFiles string
 	ConfigPaths []string
+	Updates     int  // Running containers with a newer image in their registry
+	ShowUpdates bool // Adds the UPDATE cell (image update checks enabled)
 }
 
 func (cp ComposeProject) GetID() string { return cp.Name }
 func (cp ComposeProject) GetCells() []string {
+	if cp.ShowUpdates {
+		return []string{cp.Name, cp.Ready, cp.Status, cp.updates(), cp.ConfigFiles}
+	}
 	return []string{cp.Name, cp.Ready, cp.Status, cp.ConfigFiles}
 }
 
+func (cp ComposeProject) updates() string {
+	if cp.Updates == 0 {
+		return ""
+	}
+	return fmt.Sprintf("⬆ %d", cp.Updates)
+}
+
 func (cp ComposeProject) GetStatusColor() (tcell.Color, tcell.Color) {
 	if strings.Contains(cp.Ready, "/") {
 		parts := strings.Split(cp.Ready, "/")
@@ -133,6 +145,8 @@ func (cp ComposeProject) GetColumnValue(column string) string {
 		return cp.Ready
 	case "status":
 		return cp.Status
+	case "update":
+		return cp.updates()
 	case "config files":
 		return cp.ConfigFiles
 	}
@@ -381,6 +395,15 @@ func (m *Manager) Build(projectName string) error {
 	return m.up(projectName, paths, "--build")
 }
 
+// Update pulls the project images and recreates the containers whose image changed
+func (m *Manager) Update(projectName string) error {
+	paths, err := m.getConfigPaths(projectName)
+	if err != nil {
+		return fmt.Errorf("failed to update project: %v", err)
+	}
+	return m.up(projectName, paths, "--pull=always")
+}
+
 func (m *Manager) up(projectName string, paths []string, extraFlag string) error {
 	args := []string{"compose", "-p", projectName}
 	for _, path := range paths {
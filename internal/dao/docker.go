@@ -11,8 +11,10 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"path"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/docker/cli/cli/config"
@@ -20,6 +22,7 @@ import (
 	clicontext "github.com/docker/cli/cli/context"
 	"github.com/docker/cli/cli/context/docker"
 	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/client"
 	"github.com/jr-k/d4s/internal/dao/common"
@@ -30,6 +33,7 @@ import (
 	"github.com/jr-k/d4s/internal/dao/docker/dconfig"
 	"github.com/jr-k/d4s/internal/dao/docker/secret"
 	"github.com/jr-k/d4s/internal/dao/docker/stack"
+	"github.com/jr-k/d4s/internal/dao/docker/system"
 	"github.com/jr-k/d4s/internal/dao/docker/volume"
 	"github.com/jr-k/d4s/internal/dao/swarm/node"
 	"github.com/jr-k/d4s/internal/dao/swarm/service"
@@ -42,9 +46,35 @@ import (
 type Resource = common.Resource
 type HostStats = common.HostStats
 type Container = container.Container
+type RunSpec = container.RunSpec
+type ContainerLimits = container.Limits
 type Image = image.Image
+type ImageProgress = image.Progress
+type ImageDetails = image.Details
+type DiskUsageAnalysis = system.Analysis
+type DiskUsageCandidate = system.Candidate
+type BuildCache = system.BuildCache
+type RemoteTag = image.RemoteTag
+type ScanOptions = image.ScanOptions
+type ScanReport = image.ScanReport
+type Vulnerability = image.Vulnerability
+type SBOM = image.SBOM
+type BuildOptions = image.BuildOptions
+type BuildLog = image.BuildLog
+type SBOMOptions = image.SBOMOptions
+type BuildCachePruneOptions = system.BuildCachePruneOptions
 type Volume = volume.Volume
+type VolumeSpec = volume.CreateSpec
+type FileEntry = container.FileEntry
+type FileTree = container.FileTree
+type CopyProgress = container.CopyProgress
+type Diagnosis = container.Diagnosis
+type CaptureOptions = container.CaptureOptions
 type Network = network.Network
+type NetworkSpec = network.CreateSpec
+type TopologyNetwork = network.TopologyNetwork
+type NetworkConnectSpec = network.ConnectSpec
+type NetworkEndpoint = container.NetworkEndpoint
 type Service = service.Service
 type Node = node.Node
 type Secret = secret.Secret
@@ -64,6 +94,17 @@ type PluginInfo struct {
 type containerInfoCache struct {
 	Mounts []mountInfoCache
 	NetIDs map[string]bool
+	State  *containerStateCache // Inspect-only details, nil until inspected
+}
+
+// containerStateCache holds the restart count, exit code, OOM flag and health
+// of a container, collected for a given list state and health.
+type containerStateCache struct {
+	Key          string
+	RestartCount int
+	ExitCode     int
+	OOMKilled    bool
+	Health       string
 }
 
 type mountInfoCache struct {
@@ -90,6 +131,7 @@ type DockerClient struct {
 	Stack     *stack.Manager
 	Task      *task.Manager
 	Compose   *compose.Manager
+	System    *system.Manager
 
 	// Resource cache for fast scoped queries and stale-while-revalidate
 	cacheMu             sync.RWMutex
@@ -111,6 +153,10 @@ type DockerClient struct {
 	// One-time stats throttling setup for SSH contexts
 	statsThrottleOnce sync.Once
 
+	// Containers are inspected for the state columns only while they are shown
+	containerStates atomic.Bool
+	inspecting      atomic.Bool
+
 	// One-time compose exec target setup (local vs remote SSH host)
 	composeTargetOnce sync.Once
 
@@ -151,6 +197,7 @@ func NewDockerClient(contextName string, apiTimeout time.Duration, defaultContex
 		Stack:            stack.NewManager(cli, ctx, ctxName),
 		Task:             task.NewManager(cli, ctx),
 		Compose:          compose.NewManager(cli, ctx),
+		System:           system.NewManager(cli, ctx),
 		containerInfoMap: make(map[string]containerInfoCache),
 		refreshing:       make(map[string]bool),
 	}, nil
@@ -348,7 +395,117 @@ func (d *DockerClient) ListContainers() ([]common.Resource, error) {
 			d.Container.SetMinStatsInterval(10 * time.Second)
 		}
 	})
-	return d.Container.List()
+	res, err := d.Container.List()
+	if err != nil || !d.containerStates.Load() {
+		return res, err
+	}
+
+	d.updateContainerStates(res)
+	d.cacheMu.RLock()
+	for i, r := range res {
+		c, ok := r.(container.Container)
+		if !ok {
+			continue
+		}
+		if info, ok := d.containerInfoMap[c.ID]; ok && info.State != nil {
+			c.Inspected = true
+			c.RestartCount = info.State.RestartCount
+			c.ExitCode = info.State.ExitCode
+			c.OOMKilled = info.State.OOMKilled
+			c.Health = info.State.Health
+			res[i] = c
+		}
+	}
+	d.cacheMu.RUnlock()
+	return res, nil
+}
+
+// SetContainerStates enables the inspection of containers for the RESTARTS,
+// EXIT, OOM and HEALTH columns.
+func (d *DockerClient) SetContainerStates(enabled bool) {
+	d.containerStates.Store(enabled)
+}
+
+// updateContainerStates inspects, in the background, the containers whose list
+// state or health changed since they were last inspected. Results go to the
+// container info cache, along with their mounts and networks.
+func (d *DockerClient) updateContainerStates(list []common.Resource) {
+	if !d.inspecting.CompareAndSwap(false, true) {
+		return
+	}
+
+	var stale []string
+	keys := make(map[string]string, len(list))
+	d.cacheMu.RLock()
+	for _, r := range list {
+		c, ok := r.(container.Container)
+		if !ok {
+			continue
+		}
+		key := c.State + "/" + c.ListHealth
+		keys[c.ID] = key
+		if info, ok := d.containerInfoMap[c.ID]; !ok || info.State == nil || info.State.Key != key {
+			stale = append(stale, c.ID)
+		}
+	}
+	d.cacheMu.RUnlock()
+	if len(stale) == 0 {
+		d.inspecting.Store(false)
+		return
+	}
+
+	go func() {
+		defer d.inspecting.Store(false)
+
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, 5) // Limit concurrency
+		for _, id := range stale {
+			wg.Add(1)
+			go func(id string) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				cj, err := d.Cli.ContainerInspect(d.Ctx, id)
+				if err != nil || cj.ContainerJSONBase == nil {
+					return
+				}
+				info := inspectInfo(cj)
+				info.State = &containerStateCache{Key: keys[id], RestartCount: cj.RestartCount}
+				if cj.State != nil {
+					info.State.ExitCode = cj.State.ExitCode
+					info.State.OOMKilled = cj.State.OOMKilled
+					if cj.State.Health != nil {
+						info.State.Health = cj.State.Health.Status
+					}
+				}
+
+				d.cacheMu.Lock()
+				d.containerInfoMap[id] = info
+				d.cacheMu.Unlock()
+			}(id)
+		}
+		wg.Wait()
+	}()
+}
+
+// inspectInfo builds the mount/network info of a container from its inspect data
+func inspectInfo(cj dcontainer.InspectResponse) containerInfoCache {
+	info := containerInfoCache{NetIDs: make(map[string]bool)}
+	for _, m := range cj.Mounts {
+		info.Mounts = append(info.Mounts, mountInfoCache{
+			Type:        string(m.Type),
+			Name:        m.Name,
+			Source:      m.Source,
+			Destination: m.Destination,
+		})
+	}
+	if cj.NetworkSettings != nil {
+		for _, n := range cj.NetworkSettings.Networks {
+			info.NetIDs[n.NetworkID] = true
+		}
+	}
+	return info
 }
 
 // ListImages returns cached results immediately if available, then
@@ -443,6 +600,13 @@ func (d *DockerClient) fetchVolumes() ([]common.Resource, error) {
 		}
 
 		d.cacheMu.Lock()
+		// Keep the inspect details collected for the state columns
+		for id, info := range infoMap {
+			if old, ok := d.containerInfoMap[id]; ok && old.State != nil {
+				info.State = old.State
+				infoMap[id] = info
+			}
+		}
 		d.containerInfoMap = infoMap
 		d.cacheMu.Unlock()
 	}
@@ -553,6 +717,32 @@ func (d *DockerClient) ListSecrets() ([]common.Resource, error) {
 }
 
 // Actions wrappers
+func (d *DockerClient) CreateContainer(spec RunSpec) (string, error) {
+	return d.Container.Create(spec)
+}
+
+// ClearHostPorts drops the host ports of port specs, the daemon picks them.
+func ClearHostPorts(ports []string) []string {
+	return container.ClearHostPorts(ports)
+}
+
+func (d *DockerClient) GetContainerSpec(id string) (RunSpec, error) {
+	return d.Container.Spec(id)
+}
+
+func (d *DockerClient) RecreateContainer(id string, spec RunSpec, start bool) (string, error) {
+	d.invalidateContainerInfoCache(id)
+	return d.Container.Recreate(id, spec, start)
+}
+
+func (d *DockerClient) GetContainerLimits(id string) (ContainerLimits, error) {
+	return d.Container.GetLimits(id)
+}
+
+func (d *DockerClient) UpdateContainerLimits(id string, limits ContainerLimits) error {
+	return d.Container.UpdateLimits(id, limits)
+}
+
 func (d *DockerClient) StopContainer(id string) error {
 	return d.Container.Stop(id)
 }
@@ -565,6 +755,22 @@ func (d *DockerClient) RestartContainer(id string) error {
 	return d.Container.Restart(id)
 }
 
+func (d *DockerClient) PauseContainer(id string) error {
+	return d.Container.Pause(id)
+}
+
+func (d *DockerClient) UnpauseContainer(id string) error {
+	return d.Container.Unpause(id)
+}
+
+func (d *DockerClient) KillContainer(id string, signal string) error {
+	return d.Container.Kill(id, signal)
+}
+
+func (d *DockerClient) RenameContainer(id string, name string) error {
+	return d.Container.Rename(id, name)
+}
+
 func (d *DockerClient) RemoveContainer(id string, force bool) error {
 	return d.Container.Remove(id, force)
 }
@@ -585,8 +791,380 @@ func (d *DockerClient) PullImage(tag string) error {
 	return d.Image.Pull(tag)
 }
 
-func (d *DockerClient) CreateVolume(name string) error {
-	return d.Volume.Create(name)
+// StartPullProgress registers the progress of an upcoming PullImage(tag) so it can be watched right away.
+func (d *DockerClient) StartPullProgress(tag string) *ImageProgress {
+	return d.Image.StartPull(tag)
+}
+
+func (d *DockerClient) GetPullProgress(tag string) *ImageProgress {
+	return d.Image.GetPullProgress(tag)
+}
+
+// ImageWithRepositoryPrefix moves ref under another registry/namespace, keeping name and tag.
+func ImageWithRepositoryPrefix(ref, prefix string) (string, error) {
+	return image.WithRepositoryPrefix(ref, prefix)
+}
+
+func (d *DockerClient) TagImage(source, target string) error {
+	return d.Image.Tag(source, target)
+}
+
+func (d *DockerClient) PushImage(ref string) error {
+	return d.Image.Push(ref)
+}
+
+// StartPushProgress registers the progress of an upcoming PushImage(ref) so it can be watched right away.
+func (d *DockerClient) StartPushProgress(ref string) *ImageProgress {
+	return d.Image.StartPush(ref)
+}
+
+func (d *DockerClient) GetPushProgress(ref string) *ImageProgress {
+	return d.Image.GetPushProgress(ref)
+}
+
+// SaveImages writes the images to a local tar archive and returns its size.
+func (d *DockerClient) SaveImages(refs []string, path string) (int64, error) {
+	return d.Image.Save(refs, path)
+}
+
+// LoadImages imports a local tar archive and returns the loaded references.
+func (d *DockerClient) LoadImages(path string) ([]string, error) {
+	return d.Image.Load(path)
+}
+
+// ListRemoteTags lists the tags of repo in its registry (cached unless refresh).
+func (d *DockerClient) ListRemoteTags(repo string, refresh bool) ([]common.Resource, error) {
+	return d.Image.RemoteTags(repo, refresh)
+}
+
+// ImageRepositoryName returns the repository of a reference (e.g. "nginx" for "nginx:1.27").
+func ImageRepositoryName(ref string) (string, error) {
+	return image.RepositoryName(ref)
+}
+
+func (d *DockerClient) GetImageDetails(id string) (*ImageDetails, error) {
+	return d.Image.Details(id)
+}
+
+// ScanImage runs the configured vulnerability scanner on an image against
+// this client's daemon. target is the reference (or ID) given to the scanner.
+func (d *DockerClient) ScanImage(id, target string, opts ScanOptions) (*ScanReport, error) {
+	sshHost := ""
+	if d.IsSSHContext() {
+		sshHost = d.GetSSHHost()
+	}
+	if opts.Socket == "" {
+		opts.Socket = d.daemonSocket()
+	}
+	return d.Image.Scan(id, target, opts, d.ContextName, sshHost)
+}
+
+// GetImageScanReport returns the latest scan of an image (nil if never scanned).
+func (d *DockerClient) GetImageScanReport(id string) *ScanReport {
+	return d.Image.GetScanReport(id)
+}
+
+// GenerateSBOM returns the bill of materials of an image, from its SBOM
+// attestation when published, otherwise from a syft scan.
+func (d *DockerClient) GenerateSBOM(id, target string, opts SBOMOptions) (*SBOM, error) {
+	sshHost := ""
+	if d.IsSSHContext() {
+		sshHost = d.GetSSHHost()
+	}
+	if opts.Socket == "" {
+		opts.Socket = d.daemonSocket()
+	}
+	return d.Image.SBOM(id, target, opts, d.ContextName, sshHost)
+}
+
+// daemonSocket returns the path of the daemon socket on the daemon host, for
+// the helper tools mounting it. Remote endpoints and sockets under the home
+// directory (Docker Desktop, Colima, OrbStack forward them to a VM) use the
+// standard path.
+func (d *DockerClient) daemonSocket() string {
+	path, ok := strings.CutPrefix(d.Cli.DaemonHost(), "unix://")
+	if !ok {
+		return "/var/run/docker.sock"
+	}
+	if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(path, home+"/") {
+		return "/var/run/docker.sock"
+	}
+	return path
+}
+
+// NewBuildLog returns an empty log to follow a build.
+func NewBuildLog() *BuildLog {
+	return image.NewBuildLog()
+}
+
+// BuildImage builds an image with the docker CLI (BuildKit) on this client's
+// daemon, streaming the progress into log, and returns the image ID.
+// Cancelling ctx stops the build.
+func (d *DockerClient) BuildImage(ctx context.Context, opts BuildOptions, log *BuildLog) (string, error) {
+	return image.Build(ctx, d.dockerCmdContext, opts, log)
+}
+
+// ErrBuildCancelled is returned by BuildImage when its context is cancelled
+var ErrBuildCancelled = image.ErrBuildCancelled
+
+// SBOM export formats
+const (
+	SBOMFormatSPDX      = image.SBOMFormatSPDX
+	SBOMFormatCycloneDX = image.SBOMFormatCycloneDX
+)
+
+// ScanSeverities lists the vulnerability severities, most urgent first.
+var ScanSeverities = image.Severities
+
+// ImageUpdateAvailable reports whether the registry serves a newer image for
+// ref than the local image imageID. Checks run in the background and are
+// cached for interval, so this returns false until the first check completes.
+func (d *DockerClient) ImageUpdateAvailable(ref, imageID string, interval time.Duration) bool {
+	return d.Image.UpdateStatus(ref, imageID, interval) == image.UpdateAvailable
+}
+
+// PullAndRecreateContainer pulls the image reference of a container and
+// recreates it with the same settings, restarting it if it was running.
+func (d *DockerClient) PullAndRecreateContainer(id string) (string, error) {
+	spec, err := d.Container.Spec(id)
+	if err != nil {
+		return "", err
+	}
+	if err := d.Image.Pull(spec.Image); err != nil {
+		return "", err
+	}
+	d.Image.ForgetUpdate(spec.Image)
+
+	info, err := d.Cli.ContainerInspect(d.Ctx, id)
+	if err != nil {
+		return "", err
+	}
+	start := info.State != nil && (info.State.Running || info.State.Paused)
+	return d.RecreateContainer(id, spec, start)
+}
+
+// ComposeUpdates counts, per compose project, the running containers whose
+// image has a newer version in its registry, rechecking tags every interval.
+func (d *DockerClient) ComposeUpdates(interval time.Duration) (map[string]int, error) {
+	list, err := d.Cli.ContainerList(d.Ctx, dcontainer.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", "com.docker.compose.project")),
+	})
+	if err != nil {
+		return nil, err
+	}
+	updates := make(map[string]int)
+	for _, c := range list {
+		if d.ImageUpdateAvailable(c.Image, c.ImageID, interval) {
+			updates[c.Labels["com.docker.compose.project"]]++
+		}
+	}
+	return updates, nil
+}
+
+// AnalyzeDiskUsage lists what can be reclaimed (images unused for unusedDays and older).
+func (d *DockerClient) AnalyzeDiskUsage(unusedDays int) (*DiskUsageAnalysis, error) {
+	return d.System.Analyze(unusedDays)
+}
+
+// RemoveDiskUsageCandidates deletes the candidates and returns the reclaimed size and failures.
+func (d *DockerClient) RemoveDiskUsageCandidates(candidates []DiskUsageCandidate) (int64, []string) {
+	return d.System.Remove(candidates)
+}
+
+func (d *DockerClient) ListBuildCache() ([]common.Resource, error) {
+	return d.System.ListBuildCache()
+}
+
+// PruneBuildCache removes the build cache matching opts and returns the reclaimed size.
+func (d *DockerClient) PruneBuildCache(opts BuildCachePruneOptions) (int64, error) {
+	return d.System.PruneBuildCache(opts)
+}
+
+func (d *DockerClient) RemoveBuildCache(id string) error {
+	return d.System.RemoveBuildCache(id)
+}
+
+// CreateVolume creates a volume and returns its name (generated when not set).
+func (d *DockerClient) CreateVolume(spec VolumeSpec) (string, error) {
+	return d.Volume.Create(spec)
+}
+
+// TestVolumeMount checks that a volume can be mounted (remote filesystem options).
+func (d *DockerClient) TestVolumeMount(name, image string) error {
+	if err := d.ensureImage(image); err != nil {
+		return err
+	}
+	return d.Volume.TestMount(name, image)
+}
+
+// ErrNotDirectory is returned by ListContainerFiles for a path that is not a directory
+var ErrNotDirectory = container.ErrNotDirectory
+
+// VolumeHelperMountPoint is where volume helper containers mount the volume
+const VolumeHelperMountPoint = volume.HelperMountPoint
+
+func (d *DockerClient) ListContainerFiles(id, dir string) (*FileTree, error) {
+	return d.Container.ListFiles(id, dir)
+}
+
+func (d *DockerClient) ReadContainerFile(id, path string, limit int64) ([]byte, int64, error) {
+	return d.Container.ReadFile(id, path, limit)
+}
+
+func NewCopyProgress(source, destination string) *CopyProgress {
+	return container.NewCopyProgress(source, destination)
+}
+
+// UploadToContainer streams a local file or directory to dst in the container
+// through the API, so it works the same on local and remote (SSH) daemons.
+func (d *DockerClient) UploadToContainer(id, src, dst string, p *CopyProgress) error {
+	return d.Container.Upload(id, src, dst, p)
+}
+
+// ParseDiagnoseTarget splits a diagnose target into host and port (0 when missing).
+func ParseDiagnoseTarget(target string) (string, int, error) {
+	return container.ParseDiagnoseTarget(target)
+}
+
+// DiagnoseContainer checks DNS and TCP connectivity to target from the network
+// namespace of the container, with a helper of image pulled when missing.
+func (d *DockerClient) DiagnoseContainer(id, image, target string, timeout time.Duration) (*Diagnosis, error) {
+	if err := d.ensureImage(image); err != nil {
+		return nil, err
+	}
+	return d.Container.Diagnose(id, image, target, timeout)
+}
+
+// WiresharkPath returns the local Wireshark executable, empty when not installed.
+func WiresharkPath() string {
+	return container.WiresharkPath()
+}
+
+// CaptureContainer streams a tcpdump capture of the container network
+// namespace to a local file or Wireshark, until opts.Duration or stop.
+func (d *DockerClient) CaptureContainer(id, image string, opts CaptureOptions, p *CopyProgress, stop <-chan struct{}) error {
+	if err := d.ensureImage(image); err != nil {
+		return p.Fail(err)
+	}
+	return d.Container.Capture(id, image, opts, p, stop)
+}
+
+// DownloadFromContainer streams src from the container to the local dst.
+func (d *DockerClient) DownloadFromContainer(id, src, dst string, p *CopyProgress) error {
+	return d.Container.Download(id, src, dst, p)
+}
+
+// UploadToVolume streams a local file or directory to dst in a volume,
+// mounted in a helper container of image for the transfer.
+func (d *DockerClient) UploadToVolume(name, image, src, dst string, p *CopyProgress) error {
+	helperID, err := d.CreateVolumeHelper(name, image, false)
+	if err != nil {
+		return p.Fail(err)
+	}
+	defer d.RemoveVolumeHelper(helperID)
+	defer d.Volume.InvalidateUsage()
+	return d.Container.Upload(helperID, src, path.Join(volume.HelperMountPoint, path.Clean("/"+dst)), p)
+}
+
+// DownloadFromVolume streams src from a volume to the local dst.
+func (d *DockerClient) DownloadFromVolume(name, image, src, dst string, p *CopyProgress) error {
+	helperID, err := d.CreateVolumeHelper(name, image, true)
+	if err != nil {
+		return p.Fail(err)
+	}
+	defer d.RemoveVolumeHelper(helperID)
+	return d.Container.Download(helperID, path.Join(volume.HelperMountPoint, path.Clean("/"+src)), dst, p)
+}
+
+// BackupVolume streams the content of a volume to a local .tar.gz file.
+func (d *DockerClient) BackupVolume(name, image, file string, p *CopyProgress) error {
+	helperID, err := d.CreateVolumeHelper(name, image, true)
+	if err != nil {
+		return p.Fail(err)
+	}
+	defer d.RemoveVolumeHelper(helperID)
+	return d.Container.ArchiveTo(helperID, volume.HelperMountPoint, file, p)
+}
+
+// RestoreVolume replaces the content of a volume, created when missing, with
+// a local .tar.gz (or .tar) archive.
+func (d *DockerClient) RestoreVolume(name, image, file string, p *CopyProgress) error {
+	if _, err := os.Stat(file); err != nil {
+		return p.Fail(err)
+	}
+	if err := d.ensureImage(image); err != nil {
+		return p.Fail(err)
+	}
+
+	// Nothing is touched unless the whole archive reads fine
+	if err := container.ValidateArchive(file); err != nil {
+		return p.Fail(err)
+	}
+
+	if d.Volume.Exists(name) {
+		if err := d.Volume.Empty(name, image); err != nil {
+			return p.Fail(err)
+		}
+	} else if _, err := d.Volume.Create(volume.CreateSpec{Name: name}); err != nil {
+		return p.Fail(err)
+	}
+
+	helperID, err := d.Volume.CreateHelper(name, image, false)
+	if err != nil {
+		return p.Fail(err)
+	}
+	defer d.RemoveVolumeHelper(helperID)
+	defer d.Volume.InvalidateUsage()
+	return d.Container.ExtractFrom(helperID, volume.HelperMountPoint, file, p)
+}
+
+func (d *DockerClient) VolumeExists(name string) bool {
+	return d.Volume.Exists(name)
+}
+
+// VolumeRunningContainers returns the names of the running containers using the volume.
+func (d *DockerClient) VolumeRunningContainers(name string) ([]string, error) {
+	return d.Volume.RunningContainers(name)
+}
+
+// CloneVolume creates dst as a copy of src (settings and content). It reports
+// whether dst was created as a plain local volume (src backed by a device).
+func (d *DockerClient) CloneVolume(src, dst, image string) (bool, error) {
+	if err := d.ensureImage(image); err != nil {
+		return false, err
+	}
+	defer d.Volume.InvalidateUsage()
+	return d.Volume.Clone(src, dst, image)
+}
+
+// CreateVolumeHelper creates a stopped container of image with the volume
+// mounted, pulling the image first when missing.
+func (d *DockerClient) CreateVolumeHelper(name, image string, readOnly bool) (string, error) {
+	if err := d.ensureImage(image); err != nil {
+		return "", err
+	}
+	return d.Volume.CreateHelper(name, image, readOnly)
+}
+
+// StartVolumeHelper starts an idle container of image with the volume mounted
+// read-only, pulling the image first when missing.
+func (d *DockerClient) StartVolumeHelper(name, image string) (string, error) {
+	if err := d.ensureImage(image); err != nil {
+		return "", err
+	}
+	return d.Volume.StartHelper(name, image)
+}
+
+// ensureImage pulls image when it is missing from the daemon
+func (d *DockerClient) ensureImage(image string) error {
+	if _, err := d.Cli.ImageInspect(d.Ctx, image); err == nil {
+		return nil
+	}
+	return d.Image.Pull(image)
+}
+
+func (d *DockerClient) RemoveVolumeHelper(id string) error {
+	return d.Volume.RemoveHelper(id)
 }
 
 func (d *DockerClient) RemoveVolume(id string, force bool) error {
@@ -597,16 +1175,21 @@ func (d *DockerClient) PruneVolumes() error {
 	return d.Volume.Prune()
 }
 
-func (d *DockerClient) CreateNetwork(name string) error {
-	return d.Network.Create(name)
+func (d *DockerClient) CreateNetwork(spec NetworkSpec) error {
+	return d.Network.Create(spec)
+}
+
+// NetworkTopology returns the networks with their attached containers.
+func (d *DockerClient) NetworkTopology() ([]TopologyNetwork, error) {
+	return d.Network.Topology()
 }
 
 func (d *DockerClient) RemoveNetwork(id string) error {
 	return d.Network.Remove(id)
 }
 
-func (d *DockerClient) ConnectNetwork(networkID, containerID string) error {
-	err := d.Network.Connect(networkID, containerID)
+func (d *DockerClient) ConnectNetwork(networkID, containerID string, spec NetworkConnectSpec) error {
+	err := d.Network.Connect(networkID, containerID, spec)
 	if err == nil {
 		d.invalidateContainerInfoCache(containerID)
 	}
@@ -705,6 +1288,14 @@ func (d *DockerClient) dockerCmd(args ...string) *exec.Cmd {
 	return exec.Command("docker", args...)
 }
 
+// dockerCmdContext is dockerCmd killed when ctx is done
+func (d *DockerClient) dockerCmdContext(ctx context.Context, args ...string) *exec.Cmd {
+	if d.ContextName != "" && d.ContextName != "default" {
+		args = append([]string{"--context", d.ContextName}, args...)
+	}
+	return exec.CommandContext(ctx, "docker", args...)
+}
+
 func (d *DockerClient) InspectContext(name string) (string, error) {
 	cmd := exec.Command("docker", "context", "inspect", name)
 	output, err := cmd.CombinedOutput()
@@ -887,6 +1478,13 @@ func (d *DockerClient) BuildComposeProject(projectName string) error {
 	return d.Compose.Build(projectName)
 }
 
+// UpdateComposeProject pulls the images of a project and recreates the
+// containers whose image changed.
+func (d *DockerClient) UpdateComposeProject(projectName string) error {
+	d.ensureComposeTarget()
+	return d.Compose.Update(projectName)
+}
+
 func (d *DockerClient) DownComposeProject(projectName string) error {
 	d.ensureComposeTarget()
 	return d.Compose.Down(projectName)
@@ -936,6 +1534,15 @@ func (d *DockerClient) GetContainerEnv(id string) ([]string, error) {
 	return d.Container.GetEnv(id)
 }
 
+// DescribeContainer returns the inspect JSON of a container and its network endpoints.
+func (d *DockerClient) DescribeContainer(id string) (string, []NetworkEndpoint, error) {
+	return d.Container.Describe(id)
+}
+
+func (d *DockerClient) GetContainerHealth(id string) (*dcontainer.HealthConfig, *dcontainer.Health, error) {
+	return d.Container.Health(id)
+}
+
 func (d *DockerClient) HasTTY(id string) (bool, error) {
 	return common.HasTTY(d.Cli, d.Ctx, id)
 }
@@ -1049,20 +1656,7 @@ func (d *DockerClient) ListVolumesForContainer(id string) ([]common.Resource, er
 		if err != nil {
 			return nil, err
 		}
-		info = containerInfoCache{NetIDs: make(map[string]bool)}
-		for _, m := range cj.Mounts {
-			info.Mounts = append(info.Mounts, mountInfoCache{
-				Type:        string(m.Type),
-				Name:        m.Name,
-				Source:      m.Source,
-				Destination: m.Destination,
-			})
-		}
-		if cj.NetworkSettings != nil {
-			for _, n := range cj.NetworkSettings.Networks {
-				info.NetIDs[n.NetworkID] = true
-			}
-		}
+		info = inspectInfo(cj)
 		d.cacheMu.Lock()
 		d.containerInfoMap[id] = info
 		d.cacheMu.Unlock()
@@ -1220,20 +1814,7 @@ func (d *DockerClient) ListNetworksForContainer(id string) ([]common.Resource, e
 		if err != nil {
 			return nil, err
 		}
-		info = containerInfoCache{NetIDs: make(map[string]bool)}
-		if cj.NetworkSettings != nil {
-			for _, n := range cj.NetworkSettings.Networks {
-				info.NetIDs[n.NetworkID] = true
-			}
-		}
-		for _, m := range cj.Mounts {
-			info.Mounts = append(info.Mounts, mountInfoCache{
-				Type:        string(m.Type),
-				Name:        m.Name,
-				Source:      m.Source,
-				Destination: m.Destination,
-			})
-		}
+		info = inspectInfo(cj)
 		d.cacheMu.Lock()
 		d.containerInfoMap[id] = info
 		d.cacheMu.Unlock()
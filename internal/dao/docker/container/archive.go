@@ -0,0 +1,152 @@
+package container
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// ArchiveTo writes the content of dir in the container to a local .tar.gz
+// file, with entry names relative to dir.
+func (m *Manager) ArchiveTo(id, dir, file string, p *CopyProgress) error {
+	rc, stat, err := m.cli.CopyFromContainer(m.ctx, id, dir)
+	if err != nil {
+		return p.finish(err)
+	}
+	defer rc.Close()
+	if !stat.Mode.IsDir() {
+		return p.finish(fmt.Errorf("%s: %w", dir, ErrNotDirectory))
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return p.finish(err)
+	}
+	err = rebaseArchive(f, tar.NewReader(io.TeeReader(rc, p)), p)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(file)
+	}
+	return p.finish(err)
+}
+
+// rebaseArchive gzips the archive of a directory, dropping its top entry
+func rebaseArchive(w io.Writer, tr *tar.Reader, p *CopyProgress) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		rel := rebaseName(hdr.Name)
+		if rel == "" {
+			continue
+		}
+		hdr.Name = rel
+		if hdr.Typeflag == tar.TypeDir {
+			hdr.Name += "/"
+		}
+		if hdr.Typeflag == tar.TypeLink {
+			// Hard links name another entry of the archive
+			hdr.Linkname = rebaseName(hdr.Linkname)
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			p.file()
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// rebaseName drops the top directory of an archive entry name
+func rebaseName(name string) string {
+	_, rel, _ := strings.Cut(strings.TrimLeft(path.Clean("/"+name), "/"), "/")
+	return rel
+}
+
+// archiveReader returns the tar stream of a .tar.gz or plain .tar reader
+func archiveReader(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(br)
+	}
+	return br, nil
+}
+
+// ValidateArchive reads a whole .tar.gz (or .tar) file, so that a corrupt or
+// truncated archive is caught before anything gets overwritten.
+func ValidateArchive(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err == nil && info.Size() == 0 {
+		return fmt.Errorf("%s is empty", file)
+	}
+
+	archive, err := archiveReader(f)
+	if err != nil {
+		return fmt.Errorf("invalid archive %s: %v", file, err)
+	}
+	tr := tar.NewReader(archive)
+	for {
+		_, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err == nil {
+			_, err = io.Copy(io.Discard, tr)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid archive %s: %v", file, err)
+		}
+	}
+	// Read up to the gzip trailer, which holds the checksum
+	if _, err := io.Copy(io.Discard, archive); err != nil {
+		return fmt.Errorf("invalid archive %s: %v", file, err)
+	}
+	return nil
+}
+
+// ExtractFrom unpacks a local .tar.gz (or plain .tar) file into dir of the container.
+func (m *Manager) ExtractFrom(id, dir, file string, p *CopyProgress) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return p.finish(err)
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err == nil {
+		p.setTotal(info.Size())
+	}
+
+	archive, err := archiveReader(io.TeeReader(f, p))
+	if err != nil {
+		return p.finish(err)
+	}
+	err = m.cli.CopyToContainer(m.ctx, id, dir, archive, container.CopyToContainerOptions{})
+	return p.finish(err)
+}
@@ -0,0 +1,72 @@
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateArchive(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	body := bytes.Repeat([]byte("data"), 4096)
+	if err := tw.WriteHeader(&tar.Header{Name: "file", Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(body))}); err != nil {
+		t.Fatal(err)
+	}
+	tw.Write(body)
+	tw.Close()
+	gz.Close()
+
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.tar.gz")
+	truncated := filepath.Join(dir, "truncated.tar.gz")
+	empty := filepath.Join(dir, "empty.tar.gz")
+	os.WriteFile(valid, buf.Bytes(), 0o644)
+	os.WriteFile(truncated, buf.Bytes()[:buf.Len()-10], 0o644)
+	os.WriteFile(empty, nil, 0o644)
+
+	if err := ValidateArchive(valid); err != nil {
+		t.Fatalf("valid archive: %v", err)
+	}
+	for _, file := range []string{truncated, empty} {
+		if err := ValidateArchive(file); err == nil {
+			t.Fatalf("%s: expected an error", filepath.Base(file))
+		}
+	}
+}
+
+func TestRebaseArchiveHardLinks(t *testing.T) {
+	tr := writeTestArchive(t, []tar.Header{
+		{Name: "data/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "data/file", Typeflag: tar.TypeReg, Mode: 0o644},
+		{Name: "data/link", Typeflag: tar.TypeLink, Linkname: "data/file"},
+	}, map[string]string{"data/file": "hello"})
+
+	var buf bytes.Buffer
+	if err := rebaseArchive(&buf, tr, NewCopyProgress("", "")); err != nil {
+		t.Fatal(err)
+	}
+	gz, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := tar.NewReader(gz)
+	var names []string
+	for {
+		hdr, err := out.Next()
+		if err != nil {
+			break
+		}
+		names = append(names, hdr.Name)
+		if hdr.Typeflag == tar.TypeLink && hdr.Linkname != "file" {
+			t.Fatalf("hard link to %q, want %q", hdr.Linkname, "file")
+		}
+	}
+	if len(names) != 2 || names[0] != "file" || names[1] != "link" {
+		t.Fatalf("entries = %v", names)
+	}
+}
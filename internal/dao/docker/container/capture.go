@@ -0,0 +1,155 @@
+package container
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// CaptureOptions are the settings of a packet capture in a container network namespace
+type CaptureOptions struct {
+	Interface string        // Empty for all interfaces
+	Filter    string        // BPF expression, e.g. "tcp port 5432"
+	Duration  time.Duration // 0 captures until stopped
+	File      string        // Local pcap file, optional when piping to Wireshark
+	Overwrite bool          // Replace File when it already exists
+	Wireshark bool          // Pipe the capture into a local Wireshark
+}
+
+// WiresharkPath returns the local Wireshark executable, empty when not installed.
+func WiresharkPath() string {
+	if p, err := exec.LookPath("wireshark"); err == nil {
+		return p
+	}
+	if runtime.GOOS == "darwin" {
+		p := "/Applications/Wireshark.app/Contents/MacOS/Wireshark"
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
+// Capture runs tcpdump (from image) in the network namespace of the
+// container id and streams the pcap through the attach API, so nothing is
+// written on the daemon host. It ends after opts.Duration, when stop is
+// closed or when Wireshark is closed; p counts the captured bytes.
+func (m *Manager) Capture(id, image string, opts CaptureOptions, p *CopyProgress, stop <-chan struct{}) error {
+	if opts.File == "" && !opts.Wireshark {
+		return p.finish(fmt.Errorf("a capture file or Wireshark is required"))
+	}
+
+	var writers []io.Writer
+	if opts.File != "" {
+		flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if !opts.Overwrite {
+			flag |= os.O_EXCL
+		}
+		f, err := os.OpenFile(opts.File, flag, 0o644)
+		if errors.Is(err, os.ErrExist) {
+			return p.finish(fmt.Errorf("%s already exists", opts.File))
+		}
+		if err != nil {
+			return p.finish(err)
+		}
+		defer f.Close()
+		writers = append(writers, f)
+	}
+	var wireshark *exec.Cmd
+	if opts.Wireshark {
+		path := WiresharkPath()
+		if path == "" {
+			return p.finish(fmt.Errorf("wireshark not found"))
+		}
+		wireshark = exec.Command(path, "-k", "-i", "-")
+		stdin, err := wireshark.StdinPipe()
+		if err != nil {
+			return p.finish(err)
+		}
+		if err := wireshark.Start(); err != nil {
+			return p.finish(err)
+		}
+		defer func() {
+			stdin.Close()
+			go wireshark.Wait()
+		}()
+		writers = append(writers, stdin)
+	}
+
+	iface := opts.Interface
+	if iface == "" {
+		iface = "any"
+	}
+	// -U flushes every packet, the capture is followed live
+	cmd := append([]string{"tcpdump", "-i", iface, "-U", "-w", "-"}, strings.Fields(opts.Filter)...)
+	helperID, err := m.createNetHelper(id, image, cmd, "NET_ADMIN", "NET_RAW")
+	if err != nil {
+		return p.finish(err)
+	}
+	defer m.removeHelper(helperID)
+
+	attach, err := m.cli.ContainerAttach(m.ctx, helperID, container.AttachOptions{Stream: true, Stdout: true, Stderr: true})
+	if err != nil {
+		return p.finish(err)
+	}
+	defer attach.Close()
+	if err := m.cli.ContainerStart(m.ctx, helperID, container.StartOptions{}); err != nil {
+		return p.finish(err)
+	}
+
+	// tcpdump exits cleanly on SIGINT, whatever ends the capture
+	var once sync.Once
+	interrupt := func() {
+		once.Do(func() { m.cli.ContainerKill(m.ctx, helperID, "SIGINT") })
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		var timeout <-chan time.Time
+		if opts.Duration > 0 {
+			timer := time.NewTimer(opts.Duration)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		select {
+		case <-timeout:
+		case <-stop:
+		case <-done:
+			return
+		}
+		interrupt()
+	}()
+
+	var stderr bytes.Buffer
+	_, err = stdcopy.StdCopy(io.MultiWriter(append(writers, p)...), &stderr, attach.Reader)
+	if err != nil {
+		// Wireshark was closed: stop the capture
+		interrupt()
+		if wireshark != nil && opts.File == "" {
+			err = nil
+		}
+		return p.finish(err)
+	}
+
+	statusCh, errCh := m.cli.ContainerWait(m.ctx, helperID, container.WaitConditionNotRunning)
+	select {
+	case err := <-errCh:
+		return p.finish(err)
+	case status := <-statusCh:
+		if status.StatusCode != 0 {
+			lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+			return p.finish(fmt.Errorf("tcpdump exited with code %d: %s", status.StatusCode, lines[len(lines)-1]))
+		}
+	}
+	return p.finish(nil)
+}
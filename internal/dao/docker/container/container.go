@@ -1,6 +1,7 @@
 package container
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"sort"
@@ -71,6 +72,15 @@ type Container struct {
 	IP          string
 	Cmd         string
 	Networks    map[string]string
+	ListHealth  string // Health from the list status: healthy, unhealthy, starting
+
+	// Inspect details, filled asynchronously while the state columns are shown
+	// (Inspected is false until then)
+	Inspected    bool
+	RestartCount int
+	ExitCode     int
+	OOMKilled    bool
+	Health       string
 }
 
 func (c Container) GetID() string { return c.ID }
@@ -82,6 +92,31 @@ func (c Container) GetCells() []string {
 	return []string{id, c.Names, c.Image, c.Status, c.CPU, c.Mem, c.Age, c.IP, c.Ports, c.Compose, c.Cmd, c.Created}
 }
 
+// GetStateCells returns the optional RESTARTS, EXIT, OOM and HEALTH cells.
+func (c Container) GetStateCells() []string {
+	if !c.Inspected {
+		return []string{"-", "-", "-", "-"}
+	}
+
+	exitCode := "-"
+	lower := strings.ToLower(c.State)
+	if lower == "exited" || lower == "dead" {
+		exitCode = fmt.Sprintf("%d", c.ExitCode)
+	}
+
+	oom := "-"
+	if c.OOMKilled {
+		oom = "yes"
+	}
+
+	health := c.Health
+	if health == "" {
+		health = "-"
+	}
+
+	return []string{fmt.Sprintf("%d", c.RestartCount), exitCode, oom, health}
+}
+
 func (c Container) GetStatusColor() (tcell.Color, tcell.Color) {
 	lower := strings.ToLower(c.State)
 
@@ -92,12 +127,13 @@ func (c Container) GetStatusColor() (tcell.Color, tcell.Color) {
 
 	switch lower {
 	case "running":
-		if strings.Contains(strings.ToLower(c.Status), "healthy") {
-			//return styles.ColorStatusGreen, styles.ColorBlack
-		} else if strings.Contains(strings.ToLower(c.Status), "unhealthy") {
+		// Check unhealthy first: "unhealthy" also contains "healthy"
+		if c.Health == container.Unhealthy || strings.Contains(strings.ToLower(c.Status), "unhealthy") {
 			return styles.ColorStatusRed, styles.ColorBlack
+		} else if strings.Contains(strings.ToLower(c.Status), "healthy") {
+			//return styles.ColorStatusGreen, styles.ColorBlack
 		}
-	case "paused":
+	case "paused", "pausing", "unpausing":
 		return styles.ColorStatusYellow, styles.ColorBlack
 	case "restarting":
 		return styles.ColorStatusOrange, styles.ColorBlack
@@ -140,6 +176,14 @@ func (c Container) GetColumnValue(column string) string {
 		return c.Cmd
 	case "created":
 		return c.Created
+	case "restarts":
+		return c.GetStateCells()[0]
+	case "exit":
+		return c.GetStateCells()[1]
+	case "oom":
+		return c.GetStateCells()[2]
+	case "health":
+		return c.GetStateCells()[3]
 	}
 	return ""
 }
@@ -214,6 +258,19 @@ func (m *Manager) updateStats(containers []types.Container) {
 	}()
 }
 
+// listHealth extracts the health from a list status ("Up 1 hour (healthy)")
+func listHealth(status string) string {
+	switch {
+	case strings.HasSuffix(status, "(healthy)"):
+		return "healthy"
+	case strings.HasSuffix(status, "(unhealthy)"):
+		return "unhealthy"
+	case strings.HasSuffix(status, "(health: starting)"):
+		return "starting"
+	}
+	return ""
+}
+
 func (m *Manager) List() ([]common.Resource, error) {
 	list, err := m.cli.ContainerList(m.ctx, container.ListOptions{All: true})
 	if err != nil {
@@ -317,6 +374,7 @@ func (m *Manager) List() ([]common.Resource, error) {
 			IP:          ip,
 			Cmd:         cmd,
 			Networks:    networks,
+			ListHealth:  listHealth(c.Status),
 		}
 	}
 	return res, nil
@@ -336,6 +394,23 @@ func (m *Manager) Restart(id string) error {
 	return m.cli.ContainerRestart(m.ctx, id, container.StopOptions{Timeout: &timeout})
 }
 
+func (m *Manager) Pause(id string) error {
+	return m.cli.ContainerPause(m.ctx, id)
+}
+
+func (m *Manager) Unpause(id string) error {
+	return m.cli.ContainerUnpause(m.ctx, id)
+}
+
+// Kill sends a signal (e.g. "SIGHUP", "USR1", "9") to the container's main process.
+func (m *Manager) Kill(id string, signal string) error {
+	return m.cli.ContainerKill(m.ctx, id, signal)
+}
+
+func (m *Manager) Rename(id string, name string) error {
+	return m.cli.ContainerRename(m.ctx, id, name)
+}
+
 func (m *Manager) Remove(id string, force bool) error {
 	return m.cli.ContainerRemove(m.ctx, id, container.RemoveOptions{Force: force})
 }
@@ -380,3 +455,75 @@ func (m *Manager) GetEnv(id string) ([]string, error) {
 	}
 	return c.Config.Env, nil
 }
+
+// Health returns the healthcheck configuration and the last probe results.
+// Both are nil when the container has no healthcheck.
+func (m *Manager) Health(id string) (*container.HealthConfig, *container.Health, error) {
+	c, err := m.cli.ContainerInspect(m.ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	var cfg *container.HealthConfig
+	if c.Config != nil {
+		cfg = c.Config.Healthcheck
+	}
+	var health *container.Health
+	if c.State != nil {
+		health = c.State.Health
+	}
+	return cfg, health, nil
+}
+
+// NetworkEndpoint is the attachment of a container to one network
+type NetworkEndpoint struct {
+	Network string
+	IPv4    string
+	IPv6    string
+	Static  bool     // IPv4/IPv6 requested when connecting
+	Aliases []string // User-defined, without the name and short ID added by the daemon
+	Links   []string
+}
+
+// Describe returns the inspect JSON of a container and its network endpoints,
+// from a single inspect.
+func (m *Manager) Describe(id string) (string, []NetworkEndpoint, error) {
+	c, err := m.cli.ContainerInspect(m.ctx, id)
+	if err != nil {
+		return "", nil, err
+	}
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return "", nil, err
+	}
+	return string(b), endpointsOf(c), nil
+}
+
+// endpointsOf returns the networks of a container with their addresses and aliases
+func endpointsOf(c container.InspectResponse) []NetworkEndpoint {
+	if c.NetworkSettings == nil {
+		return nil
+	}
+
+	name := strings.TrimPrefix(c.Name, "/")
+	var res []NetworkEndpoint
+	for netName, settings := range c.NetworkSettings.Networks {
+		if settings == nil {
+			continue
+		}
+		ep := NetworkEndpoint{
+			Network: netName,
+			IPv4:    settings.IPAddress,
+			IPv6:    settings.GlobalIPv6Address,
+			Static:  settings.IPAMConfig != nil && (settings.IPAMConfig.IPv4Address != "" || settings.IPAMConfig.IPv6Address != ""),
+			Links:   settings.Links,
+		}
+		for _, alias := range settings.Aliases {
+			if alias != name && !strings.HasPrefix(c.ID, alias) {
+				ep.Aliases = append(ep.Aliases, alias)
+			}
+		}
+		res = append(res, ep)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Network < res[j].Network })
+	return res
+}
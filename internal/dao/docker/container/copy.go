@@ -0,0 +1,292 @@
+package container
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+)
+
+// CopyProgress tracks a file transfer between this machine and a container
+type CopyProgress struct {
+	Source      string
+	Destination string
+	Started     time.Time
+
+	mu      sync.Mutex
+	current int64
+	total   int64 // 0 when unknown (directory downloads)
+	files   int
+	done    bool
+	err     error
+	ended   time.Time
+}
+
+func NewCopyProgress(source, destination string) *CopyProgress {
+	return &CopyProgress{Source: source, Destination: destination, Started: time.Now()}
+}
+
+// Snapshot returns the transferred and expected bytes, the number of files and the state.
+func (p *CopyProgress) Snapshot() (current, total int64, files int, done bool, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.current, p.total, p.files, p.done, p.err
+}
+
+func (p *CopyProgress) Elapsed() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done {
+		return p.ended.Sub(p.Started)
+	}
+	return time.Since(p.Started)
+}
+
+func (p *CopyProgress) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	p.current += int64(len(b))
+	p.mu.Unlock()
+	return len(b), nil
+}
+
+func (p *CopyProgress) file() {
+	p.mu.Lock()
+	p.files++
+	p.mu.Unlock()
+}
+
+func (p *CopyProgress) setTotal(total int64) {
+	p.mu.Lock()
+	p.total = total
+	p.mu.Unlock()
+}
+
+// Fail marks the transfer as failed before it could start.
+func (p *CopyProgress) Fail(err error) error {
+	return p.finish(err)
+}
+
+func (p *CopyProgress) finish(err error) error {
+	p.mu.Lock()
+	p.done, p.err, p.ended = true, err, time.Now()
+	p.mu.Unlock()
+	return err
+}
+
+// Upload copies a local file or directory to dst in the container, like
+// docker cp: into dst when it is an existing directory, as dst otherwise.
+func (m *Manager) Upload(id, src, dst string, p *CopyProgress) error {
+	src = filepath.Clean(src)
+	info, err := os.Lstat(src)
+	if err != nil {
+		return p.finish(err)
+	}
+
+	dst = path.Clean("/" + dst)
+	dir, name := dst, filepath.Base(src)
+	if stat, err := m.cli.ContainerStatPath(m.ctx, id, dst); err != nil {
+		if !client.IsErrNotFound(err) {
+			return p.finish(err)
+		}
+		dir, name = path.Dir(dst), path.Base(dst)
+	} else if !stat.Mode.IsDir() {
+		if info.IsDir() {
+			return p.finish(fmt.Errorf("%s is not a directory", dst))
+		}
+		dir, name = path.Dir(dst), path.Base(dst)
+	}
+
+	var total int64
+	filepath.Walk(src, func(_ string, fi os.FileInfo, err error) error {
+		if err == nil && fi.Mode().IsRegular() {
+			total += fi.Size()
+		}
+		return nil
+	})
+	p.setTotal(total)
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeTar(pw, src, name, p))
+	}()
+
+	err = m.cli.CopyToContainer(m.ctx, id, dir, pr, container.CopyToContainerOptions{})
+	pr.CloseWithError(err)
+	return p.finish(err)
+}
+
+// writeTar archives src under name, counting file contents in p
+func writeTar(w io.Writer, src, name string, p *CopyProgress) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(src, func(file string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, file)
+		if err != nil {
+			return err
+		}
+
+		link := ""
+		if fi.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(fi, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = path.Join(name, filepath.ToSlash(rel))
+		if fi.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if _, err := io.Copy(io.MultiWriter(tw, p), f); err != nil {
+			return err
+		}
+		p.file()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// Download copies a file or directory of the container to the local dst,
+// like docker cp: into dst when it is an existing directory, as dst otherwise.
+func (m *Manager) Download(id, src, dst string, p *CopyProgress) error {
+	rc, stat, err := m.cli.CopyFromContainer(m.ctx, id, src)
+	if err != nil {
+		return p.finish(err)
+	}
+	defer rc.Close()
+	if !stat.Mode.IsDir() {
+		p.setTotal(stat.Size)
+	}
+
+	dst = filepath.Clean(dst)
+	target := dst
+	if info, err := os.Stat(dst); err == nil && info.IsDir() {
+		target = filepath.Join(dst, stat.Name)
+	} else if err == nil && stat.Mode.IsDir() {
+		return p.finish(fmt.Errorf("%s is not a directory", dst))
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return p.finish(err)
+	}
+
+	return p.finish(extractTar(tar.NewReader(rc), target, p))
+}
+
+// extractTar writes the archive of a single file or directory as target,
+// refusing entries that would land outside of it
+func extractTar(tr *tar.Reader, target string, p *CopyProgress) error {
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Entry names start with the base name of the source
+		rel := strings.TrimLeft(path.Clean("/"+hdr.Name), "/")
+		if _, rest, ok := strings.Cut(rel, "/"); ok {
+			rel = rest
+		} else {
+			rel = ""
+		}
+		file := filepath.Join(target, filepath.FromSlash(rel))
+		if file != target && !strings.HasPrefix(file, target+string(filepath.Separator)) {
+			return fmt.Errorf("invalid archive entry %q", hdr.Name)
+		}
+		// A symlink of the archive must not redirect later entries outside target
+		if err := checkNoSymlink(target, file); err != nil {
+			return fmt.Errorf("invalid archive entry %q: %w", hdr.Name, err)
+		}
+
+		mode := os.FileMode(hdr.Mode).Perm()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(file, mode|0o700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+				return err
+			}
+			// Replace a symlink instead of writing through it
+			if fi, err := os.Lstat(file); err == nil && fi.Mode()&os.ModeSymlink != 0 {
+				if err := os.Remove(file); err != nil {
+					return err
+				}
+			}
+			f, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(io.MultiWriter(f, p), tr)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				return err
+			}
+			os.Chtimes(file, hdr.ModTime, hdr.ModTime)
+			p.file()
+		case tar.TypeSymlink:
+			os.Remove(file)
+			if err := os.Symlink(hdr.Linkname, file); err != nil {
+				return err
+			}
+		}
+		// Hard links, devices and fifos are skipped
+	}
+}
+
+// checkNoSymlink refuses file when one of its parent directories below
+// target is a symlink
+func checkNoSymlink(target, file string) error {
+	rel, err := filepath.Rel(target, filepath.Dir(file))
+	if err != nil || rel == "." {
+		return err
+	}
+	dir := target
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		dir = filepath.Join(dir, part)
+		fi, err := os.Lstat(dir)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if fi.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("%s is a symlink", dir)
+		}
+	}
+	return nil
+}
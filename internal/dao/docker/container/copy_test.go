@@ -0,0 +1,103 @@
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestArchive(t *testing.T, entries []tar.Header, contents map[string]string) *tar.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, hdr := range entries {
+		hdr := hdr
+		body := ""
+		if hdr.Typeflag == tar.TypeReg {
+			body = contents[hdr.Name]
+		}
+		hdr.Size = int64(len(body))
+		if err := tw.WriteHeader(&hdr); err != nil {
+			t.Fatal(err)
+		}
+		if body != "" {
+			if _, err := tw.Write([]byte(body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return tar.NewReader(&buf)
+}
+
+func TestExtractTarRefusesWritesThroughSymlinks(t *testing.T) {
+	tmp := t.TempDir()
+	outside := filepath.Join(tmp, "outside")
+	if err := os.Mkdir(outside, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	target := filepath.Join(tmp, "download")
+
+	tr := writeTestArchive(t, []tar.Header{
+		{Name: "src/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "src/x", Typeflag: tar.TypeSymlink, Linkname: outside},
+		{Name: "src/x/authorized_keys", Typeflag: tar.TypeReg, Mode: 0o644},
+	}, map[string]string{"src/x/authorized_keys": "ssh-ed25519 AAAA"})
+
+	if err := extractTar(tr, target, NewCopyProgress("src", target)); err == nil {
+		t.Fatal("expected an error for an entry written through a symlink")
+	}
+	if _, err := os.Stat(filepath.Join(outside, "authorized_keys")); !os.IsNotExist(err) {
+		t.Fatalf("file written outside of the destination (stat err: %v)", err)
+	}
+}
+
+func TestExtractTarReplacesSymlinkedFile(t *testing.T) {
+	tmp := t.TempDir()
+	victim := filepath.Join(tmp, "victim")
+	if err := os.WriteFile(victim, []byte("keep"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	target := filepath.Join(tmp, "download")
+
+	tr := writeTestArchive(t, []tar.Header{
+		{Name: "src/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "src/f", Typeflag: tar.TypeSymlink, Linkname: victim},
+		{Name: "src/f", Typeflag: tar.TypeReg, Mode: 0o644},
+	}, map[string]string{"src/f": "new"})
+
+	if err := extractTar(tr, target, NewCopyProgress("src", target)); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(victim); string(data) != "keep" {
+		t.Fatalf("file outside of the destination was overwritten: %q", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(target, "f")); string(data) != "new" {
+		t.Fatalf("unexpected content %q", data)
+	}
+}
+
+func TestExtractTarRegularDownload(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "download")
+	tr := writeTestArchive(t, []tar.Header{
+		{Name: "src/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "src/sub/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "src/sub/a.txt", Typeflag: tar.TypeReg, Mode: 0o644},
+		{Name: "src/link", Typeflag: tar.TypeSymlink, Linkname: "sub/a.txt"},
+	}, map[string]string{"src/sub/a.txt": "hello"})
+
+	p := NewCopyProgress("src", target)
+	if err := extractTar(tr, target, p); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(target, "link")); string(data) != "hello" {
+		t.Fatalf("unexpected content %q", data)
+	}
+	if current, _, files, _, _ := p.Snapshot(); current != 5 || files != 1 {
+		t.Fatalf("progress = %d bytes, %d files", current, files)
+	}
+}
@@ -0,0 +1,191 @@
+package container
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// diagnoseScript runs in the network namespace of the source container:
+// $1 host, $2 port (0 to skip TCP), $3 timeout in seconds, $4 "name" to resolve $1.
+// Each section starts with an "@@" marker line parsed by parseDiagnose.
+const diagnoseScript = `h="$1"; p="$2"; t="$3"
+echo "@@ resolv"; grep -E '^(nameserver|search)' /etc/resolv.conf
+echo "@@ hosts"; awk -v h="$h" '!/^#/ { for (i = 2; i <= NF; i++) if ($i == h) print $1 }' /etc/hosts
+if [ "$4" = name ]; then
+  for ty in A AAAA; do
+    echo "@@ dns $ty"; dig +time="$t" +tries=1 +noall +comments +answer +stats "$h" "$ty" 2>&1
+  done
+fi
+if [ "$p" != 0 ]; then
+  echo "@@ tcp"
+  case "$h" in *:*) a="[$h]" ;; *) a="$h" ;; esac
+  curl -gsS -o /dev/null --connect-timeout "$t" --max-time "$((t + 1))" -w '@@ connect %{time_connect} %{remote_ip}\n' "telnet://$a:$p" </dev/null 2>&1
+fi
+`
+
+// DNSLookup is the answer to one DNS query of a diagnosis
+type DNSLookup struct {
+	Type      string // A or AAAA
+	Status    string // NOERROR, NXDOMAIN, SERVFAIL...
+	Addresses []string
+	Time      time.Duration
+	Error     string
+}
+
+// Diagnosis is the result of a DNS and TCP check run from a container
+type Diagnosis struct {
+	Host        string
+	Port        int // 0 when only name resolution is checked
+	Nameservers []string
+	Search      []string
+	Hosts       []string // Addresses of the host in /etc/hosts
+	Lookups     []DNSLookup
+
+	Connected   bool
+	RemoteIP    string
+	ConnectTime time.Duration
+	TCPError    string
+}
+
+// Resolved reports whether the host resolved to at least one address.
+func (d *Diagnosis) Resolved() bool {
+	if len(d.Hosts) > 0 || len(d.Lookups) == 0 {
+		return true
+	}
+	for _, l := range d.Lookups {
+		if len(l.Addresses) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseDiagnoseTarget splits "host", "host:port" or "[v6]:port".
+func ParseDiagnoseTarget(target string) (string, int, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return "", 0, fmt.Errorf("target is required")
+	}
+	host, portStr, err := net.SplitHostPort(target)
+	if err != nil {
+		// No port, or a bare IPv6 address
+		return strings.Trim(target, "[]"), 0, nil
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > 65535 {
+		return "", 0, fmt.Errorf("invalid port %q", portStr)
+	}
+	if host == "" {
+		return "", 0, fmt.Errorf("host is required")
+	}
+	return host, port, nil
+}
+
+// Diagnose resolves target and connects to it (when it has a port) from the
+// network namespace of the container id, with a helper of image (which needs
+// sh, dig and curl, like nicolaka/netshoot). Everything runs on the daemon
+// side, so it works over SSH too.
+func (m *Manager) Diagnose(id, image, target string, timeout time.Duration) (*Diagnosis, error) {
+	host, port, err := ParseDiagnoseTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	secs := max(int(timeout.Seconds()), 1)
+	mode := "name"
+	if _, err := netip.ParseAddr(host); err == nil {
+		mode = "ip"
+	}
+
+	cmd := []string{"sh", "-c", diagnoseScript, "sh", host, strconv.Itoa(port), strconv.Itoa(secs), mode}
+	out, err := m.runNetHelper(id, image, cmd, time.Duration(4*secs)*time.Second+30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Diagnosis{Host: host, Port: port}
+	parseDiagnose(out, d)
+	return d, nil
+}
+
+func parseDiagnose(out string, d *Diagnosis) {
+	section := ""
+	var lookup *DNSLookup
+	var tcpErrors []string
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(line, "@@ "); ok {
+			fields := strings.Fields(rest)
+			switch fields[0] {
+			case "dns":
+				d.Lookups = append(d.Lookups, DNSLookup{Type: fields[len(fields)-1]})
+				lookup = &d.Lookups[len(d.Lookups)-1]
+			case "connect":
+				if len(fields) > 1 {
+					if secs, err := strconv.ParseFloat(fields[1], 64); err == nil && secs > 0 {
+						d.Connected = true
+						d.ConnectTime = time.Duration(secs * float64(time.Second))
+					}
+				}
+				if len(fields) > 2 {
+					d.RemoteIP = fields[2]
+				}
+				continue
+			}
+			section = fields[0]
+			continue
+		}
+
+		fields := strings.Fields(line)
+		switch section {
+		case "resolv":
+			if len(fields) > 1 && fields[0] == "nameserver" {
+				d.Nameservers = append(d.Nameservers, fields[1])
+			} else if len(fields) > 1 {
+				d.Search = append(d.Search, fields[1:]...)
+			}
+		case "hosts":
+			d.Hosts = append(d.Hosts, fields[0])
+		case "dns":
+			parseDigLine(line, fields, lookup)
+		case "tcp":
+			tcpErrors = append(tcpErrors, strings.TrimPrefix(line, "curl: "))
+		}
+	}
+
+	// A timeout after the connection is expected: nothing is sent to the server
+	if !d.Connected && len(tcpErrors) > 0 {
+		d.TCPError = strings.Join(tcpErrors, "; ")
+	}
+}
+
+func parseDigLine(line string, fields []string, l *DNSLookup) {
+	switch {
+	case strings.Contains(line, "status:"):
+		_, rest, _ := strings.Cut(line, "status:")
+		l.Status = strings.TrimSpace(strings.Split(rest, ",")[0])
+	case strings.HasPrefix(line, ";; Query time:"):
+		if len(fields) > 3 {
+			if ms, err := strconv.Atoi(fields[3]); err == nil {
+				l.Time = time.Duration(ms) * time.Millisecond
+			}
+		}
+	case strings.HasPrefix(line, ";"):
+		if strings.Contains(line, "timed out") || strings.Contains(line, "no servers") {
+			l.Error = strings.TrimLeft(line, "; ")
+		}
+	case len(fields) >= 5 && fields[2] == "IN":
+		if fields[3] == l.Type {
+			l.Addresses = append(l.Addresses, fields[4])
+		}
+	default:
+		// Not dig output (e.g. the helper image has no dig)
+		l.Error = line
+	}
+}
@@ -0,0 +1,305 @@
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// An archive listing stops after this many entries (the daemon sends whole subtrees)
+const fileTreeLimit = 20000
+
+// A find listing in a running container gives up after this long
+const listTimeout = 30 * time.Second
+
+// listScript prints one line per entry of the directory $1: "f <raw mode hex>
+// <size> <mtime> ./<name>", then "l ./<name>\t<target>" per symlink.
+// It exits 3 when $1 is not a directory.
+const listScript = `[ -d "$1" ] || { [ -e "$1" ] && exit 3; exit 2; }
+cd -- "$1" || exit 2
+find . -mindepth 1 -maxdepth 1 -exec stat -c 'f %f %s %Y %n' -- {} + || exit 1
+find . -mindepth 1 -maxdepth 1 -type l -exec sh -c 'for l do printf "l %s\t%s\n" "$l" "$(readlink -- "$l")"; done' sh {} +`
+
+// ErrNotDirectory is returned when listing a path that is not a directory
+var ErrNotDirectory = errors.New("not a directory")
+
+// FileEntry is a file or directory inside a container filesystem
+type FileEntry struct {
+	Name    string
+	Path    string // Absolute path in the container
+	Dir     bool
+	Link    string // Symlink target
+	Size    int64
+	Mode    os.FileMode
+	ModTime time.Time
+}
+
+// FileTree indexes the subtree of a directory, read from a container archive
+type FileTree struct {
+	Root string
+	Dirs map[string][]FileEntry // Children per absolute directory path
+
+	// Set when the listing stopped early: the directories that were still
+	// being read are incomplete and must be listed on their own.
+	Truncated bool
+	Partial   map[string]bool
+}
+
+// Complete reports whether the children of dir are all known.
+func (t *FileTree) Complete(dir string) bool {
+	_, ok := t.Dirs[dir]
+	return ok && !t.Partial[dir]
+}
+
+// Walk calls fn for every indexed entry below dir, depth first.
+func (t *FileTree) Walk(dir string, fn func(FileEntry)) {
+	for _, e := range t.Dirs[dir] {
+		fn(e)
+		if e.Dir {
+			t.Walk(e.Path, fn)
+		}
+	}
+}
+
+// ListFiles lists dir. In a running container with find and stat only the
+// entries of dir are read; otherwise (stopped or minimal containers) dir and
+// its subdirectories are indexed from the container archive of dir, which
+// works on stopped containers too (the daemon mounts their volumes).
+func (m *Manager) ListFiles(id, dir string) (*FileTree, error) {
+	dir = path.Clean("/" + dir)
+	tree, err := m.findFiles(id, dir)
+	if err == nil || errors.Is(err, ErrNotDirectory) {
+		return tree, err
+	}
+	return m.archiveFiles(id, dir)
+}
+
+// findFiles lists the entries of dir with find in the running container id
+func (m *Manager) findFiles(id, dir string) (*FileTree, error) {
+	info, err := m.cli.ContainerInspect(m.ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if info.State == nil || !info.State.Running {
+		return nil, errors.New("container is not running")
+	}
+
+	ctx, cancel := context.WithTimeout(m.ctx, listTimeout)
+	defer cancel()
+	exec, err := m.cli.ContainerExecCreate(ctx, id, container.ExecOptions{
+		Cmd:          []string{"sh", "-c", listScript, "sh", dir},
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.cli.ContainerExecAttach(ctx, exec.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	var out bytes.Buffer
+	if _, err := stdcopy.StdCopy(&out, io.Discard, resp.Reader); err != nil {
+		return nil, err
+	}
+
+	status, err := m.cli.ContainerExecInspect(ctx, exec.ID)
+	if err != nil {
+		return nil, err
+	}
+	switch status.ExitCode {
+	case 0:
+	case 3:
+		return nil, fmt.Errorf("%s: %w", dir, ErrNotDirectory)
+	default:
+		return nil, fmt.Errorf("listing %s exited with code %d", dir, status.ExitCode)
+	}
+
+	tree := &FileTree{Root: dir, Dirs: map[string][]FileEntry{dir: parseListing(dir, out.String())}, Partial: make(map[string]bool)}
+	return tree, nil
+}
+
+// parseListing reads the output of listScript run in dir
+func parseListing(dir, out string) []FileEntry {
+	var entries []FileEntry
+	links := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(line, "f "):
+			fields := strings.SplitN(line, " ", 5)
+			if len(fields) < 5 {
+				continue
+			}
+			raw, err := strconv.ParseUint(fields[1], 16, 32)
+			if err != nil {
+				continue
+			}
+			size, _ := strconv.ParseInt(fields[2], 10, 64)
+			mtime, _ := strconv.ParseInt(fields[3], 10, 64)
+			name := strings.TrimPrefix(fields[4], "./")
+			mode := fileMode(uint32(raw))
+			entries = append(entries, FileEntry{
+				Name:    name,
+				Path:    path.Join(dir, name),
+				Dir:     mode.IsDir(),
+				Size:    size,
+				Mode:    mode,
+				ModTime: time.Unix(mtime, 0),
+			})
+		case strings.HasPrefix(line, "l "):
+			name, target, found := strings.Cut(strings.TrimPrefix(line, "l "), "\t")
+			if found {
+				links[strings.TrimPrefix(name, "./")] = target
+			}
+		}
+	}
+	for i := range entries {
+		entries[i].Link = links[entries[i].Name]
+	}
+	sortFileEntries(entries)
+	return entries
+}
+
+// fileMode converts a raw st_mode to an os.FileMode
+func fileMode(raw uint32) os.FileMode {
+	mode := os.FileMode(raw & 0o777)
+	switch raw & 0o170000 {
+	case 0o040000:
+		mode |= os.ModeDir
+	case 0o120000:
+		mode |= os.ModeSymlink
+	case 0o010000:
+		mode |= os.ModeNamedPipe
+	case 0o140000:
+		mode |= os.ModeSocket
+	case 0o020000:
+		mode |= os.ModeDevice | os.ModeCharDevice
+	case 0o060000:
+		mode |= os.ModeDevice
+	}
+	if raw&0o4000 != 0 {
+		mode |= os.ModeSetuid
+	}
+	if raw&0o2000 != 0 {
+		mode |= os.ModeSetgid
+	}
+	if raw&0o1000 != 0 {
+		mode |= os.ModeSticky
+	}
+	return mode
+}
+
+// archiveFiles indexes dir and its subdirectories from the container archive of dir
+func (m *Manager) archiveFiles(id, dir string) (*FileTree, error) {
+	rc, stat, err := m.cli.CopyFromContainer(m.ctx, id, dir)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	if !stat.Mode.IsDir() {
+		return nil, fmt.Errorf("%s: %w", dir, ErrNotDirectory)
+	}
+
+	tree := &FileTree{Root: dir, Dirs: map[string][]FileEntry{dir: nil}, Partial: make(map[string]bool)}
+	// Archive names start with the base name of dir
+	parent := path.Dir(dir)
+
+	tr := tar.NewReader(rc)
+	for n := 0; ; n++ {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		p := path.Join(parent, path.Clean("/"+hdr.Name))
+		if p == dir || !strings.HasPrefix(p, strings.TrimSuffix(dir, "/")+"/") {
+			continue
+		}
+		if n >= fileTreeLimit {
+			tree.Truncated = true
+			for d := path.Dir(p); ; d = path.Dir(d) {
+				tree.Partial[d] = true
+				if d == dir || d == "/" {
+					break
+				}
+			}
+			break
+		}
+
+		entry := FileEntry{
+			Name:    path.Base(p),
+			Path:    p,
+			Dir:     hdr.Typeflag == tar.TypeDir,
+			Size:    hdr.Size,
+			Mode:    hdr.FileInfo().Mode(),
+			ModTime: hdr.ModTime,
+		}
+		if hdr.Typeflag == tar.TypeSymlink || hdr.Typeflag == tar.TypeLink {
+			entry.Link = hdr.Linkname
+		}
+		if entry.Dir {
+			if _, ok := tree.Dirs[p]; !ok {
+				tree.Dirs[p] = nil
+			}
+		}
+		d := path.Dir(p)
+		tree.Dirs[d] = append(tree.Dirs[d], entry)
+	}
+
+	for _, entries := range tree.Dirs {
+		sortFileEntries(entries)
+	}
+	return tree, nil
+}
+
+// sortFileEntries lists directories first, then files, by name
+func sortFileEntries(entries []FileEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Dir != entries[j].Dir {
+			return entries[i].Dir
+		}
+		return entries[i].Name < entries[j].Name
+	})
+}
+
+// ReadFile returns up to limit bytes of a regular file and its full size.
+func (m *Manager) ReadFile(id, file string, limit int64) ([]byte, int64, error) {
+	rc, stat, err := m.cli.CopyFromContainer(m.ctx, id, file)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rc.Close()
+	if stat.Mode.IsDir() {
+		return nil, 0, fmt.Errorf("%s is a directory", file)
+	}
+
+	tr := tar.NewReader(rc)
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, 0, err
+	}
+	if hdr.Typeflag == tar.TypeSymlink {
+		return nil, 0, fmt.Errorf("%s is a symlink to %s", file, hdr.Linkname)
+	}
+	data, err := io.ReadAll(io.LimitReader(tr, limit))
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, hdr.Size, nil
+}
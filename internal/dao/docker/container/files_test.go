@@ -0,0 +1,29 @@
+package container
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseListing(t *testing.T) {
+	out := "f 41ed 4096 1700000000 ./etc\n" +
+		"f 81a4 12 1700000001 ./my file.txt\n" +
+		"f a1ff 7 1700000002 ./current\n" +
+		"l ./current\tetc/app\n"
+
+	entries := parseListing("/srv", out)
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3: %+v", len(entries), entries)
+	}
+
+	dir, file, link := entries[0], entries[2], entries[1]
+	if dir.Name != "etc" || dir.Path != "/srv/etc" || !dir.Dir || dir.Mode != os.ModeDir|0o755 {
+		t.Fatalf("directory entry: %+v", dir)
+	}
+	if file.Name != "my file.txt" || file.Size != 12 || file.Mode != 0o644 || file.ModTime.Unix() != 1700000001 {
+		t.Fatalf("file entry: %+v", file)
+	}
+	if link.Name != "current" || link.Link != "etc/app" || link.Mode&os.ModeSymlink == 0 {
+		t.Fatalf("symlink entry: %+v", link)
+	}
+}
@@ -0,0 +1,211 @@
+package container
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+)
+
+// Limits holds the resources that can be changed on a live container, as
+// displayed/typed by the user. Empty fields are left unchanged on update.
+type Limits struct {
+	CPUs              string
+	CPUShares         string
+	CPUPeriod         string
+	CPUQuota          string
+	Memory            string
+	MemoryReservation string
+	MemorySwap        string
+	PidsLimit         string
+	RestartPolicy     string
+}
+
+// GetLimits returns the current resource limits of a container.
+func (m *Manager) GetLimits(id string) (Limits, error) {
+	info, err := m.cli.ContainerInspect(m.ctx, id)
+	if err != nil {
+		return Limits{}, err
+	}
+	if info.ContainerJSONBase == nil || info.HostConfig == nil {
+		return Limits{}, fmt.Errorf("incomplete inspect data for %s", id)
+	}
+
+	hc := info.HostConfig
+	l := Limits{}
+	if hc.NanoCPUs > 0 {
+		l.CPUs = strconv.FormatFloat(float64(hc.NanoCPUs)/1e9, 'f', -1, 64)
+	}
+	if hc.CPUShares > 0 {
+		l.CPUShares = strconv.FormatInt(hc.CPUShares, 10)
+	}
+	if hc.CPUPeriod > 0 {
+		l.CPUPeriod = strconv.FormatInt(hc.CPUPeriod, 10)
+	}
+	if hc.CPUQuota > 0 {
+		l.CPUQuota = strconv.FormatInt(hc.CPUQuota, 10)
+	}
+	if hc.Memory > 0 {
+		l.Memory = formatMemory(hc.Memory)
+	}
+	if hc.MemoryReservation > 0 {
+		l.MemoryReservation = formatMemory(hc.MemoryReservation)
+	}
+	if hc.MemorySwap > 0 {
+		l.MemorySwap = formatMemory(hc.MemorySwap)
+	} else if hc.MemorySwap == -1 {
+		l.MemorySwap = "-1"
+	}
+	if hc.PidsLimit != nil && *hc.PidsLimit > 0 {
+		l.PidsLimit = strconv.FormatInt(*hc.PidsLimit, 10)
+	}
+
+	rp := string(hc.RestartPolicy.Name)
+	if hc.RestartPolicy.Name == container.RestartPolicyOnFailure && hc.RestartPolicy.MaximumRetryCount > 0 {
+		rp = fmt.Sprintf("%s:%d", rp, hc.RestartPolicy.MaximumRetryCount)
+	}
+	l.RestartPolicy = rp
+
+	return l, nil
+}
+
+// Changes returns the fields of l that differ from current, the others
+// emptied so that they are left unchanged. When only the memory changes and
+// the swap is the daemon default (twice the memory), the swap follows, as the
+// daemon refuses a memory limit above the current swap.
+func (l Limits) Changes(current Limits) Limits {
+	changed := func(value, old string) string {
+		if value = strings.TrimSpace(value); value == strings.TrimSpace(old) {
+			return ""
+		}
+		return value
+	}
+	c := Limits{
+		CPUs:              changed(l.CPUs, current.CPUs),
+		CPUShares:         changed(l.CPUShares, current.CPUShares),
+		CPUPeriod:         changed(l.CPUPeriod, current.CPUPeriod),
+		CPUQuota:          changed(l.CPUQuota, current.CPUQuota),
+		Memory:            changed(l.Memory, current.Memory),
+		MemoryReservation: changed(l.MemoryReservation, current.MemoryReservation),
+		MemorySwap:        changed(l.MemorySwap, current.MemorySwap),
+		PidsLimit:         changed(l.PidsLimit, current.PidsLimit),
+		RestartPolicy:     changed(l.RestartPolicy, current.RestartPolicy),
+	}
+
+	if c.Memory != "" && c.MemorySwap == "" {
+		oldMem, err1 := parseMemory("memory", current.Memory)
+		oldSwap, err2 := parseMemory("memory swap", current.MemorySwap)
+		newMem, err3 := parseMemory("memory", c.Memory)
+		if err1 == nil && err2 == nil && err3 == nil && oldMem > 0 && oldSwap == 2*oldMem && newMem > 0 {
+			c.MemorySwap = formatMemory(2 * newMem)
+		}
+	}
+	return c
+}
+
+// errNoRemove is returned for limits docker update can't remove (0 means unchanged for the daemon)
+func errNoRemove(name string) error {
+	return fmt.Errorf("%s can't be removed from an existing container, recreate it (shift-e) without it", name)
+}
+
+// UpdateLimits applies new resource limits to a container (docker update).
+// Empty fields are left unchanged. The CPU quota and the swap are removed
+// with -1, the PIDs limit with 0 or -1.
+func (m *Manager) UpdateLimits(id string, l Limits) error {
+	var upd container.UpdateConfig
+	var err error
+
+	if l.CPUs != "" {
+		cpus, err := strconv.ParseFloat(l.CPUs, 64)
+		if err != nil || cpus < 0 {
+			return fmt.Errorf("invalid cpus: %q", l.CPUs)
+		}
+		if cpus == 0 {
+			return errNoRemove("the CPUs limit")
+		}
+		upd.NanoCPUs = int64(cpus * 1e9)
+	}
+	if upd.CPUShares, err = parseLimit("cpu shares", l.CPUShares); err != nil {
+		return err
+	}
+	if upd.CPUPeriod, err = parseLimit("cpu period", l.CPUPeriod); err != nil {
+		return err
+	}
+	if q := strings.TrimSpace(l.CPUQuota); q == "-1" || q == "0" {
+		upd.CPUQuota = -1
+	} else if upd.CPUQuota, err = parseInt("cpu quota", q); err != nil {
+		return err
+	}
+	if upd.Memory, err = parseMemoryLimit("memory", l.Memory); err != nil {
+		return err
+	}
+	if upd.MemoryReservation, err = parseMemoryLimit("memory reservation", l.MemoryReservation); err != nil {
+		return err
+	}
+	if strings.TrimSpace(l.MemorySwap) == "-1" {
+		upd.MemorySwap = -1
+	} else if upd.MemorySwap, err = parseMemoryLimit("memory swap", l.MemorySwap); err != nil {
+		return err
+	}
+	if p := strings.TrimSpace(l.PidsLimit); p != "" {
+		pids := int64(-1)
+		if p != "-1" && p != "0" {
+			if pids, err = parseInt("pids limit", p); err != nil {
+				return err
+			}
+		}
+		upd.PidsLimit = &pids
+	}
+	if strings.TrimSpace(l.RestartPolicy) != "" {
+		if upd.RestartPolicy, err = parseRestartPolicy(l.RestartPolicy); err != nil {
+			return err
+		}
+	}
+
+	_, err = m.cli.ContainerUpdate(m.ctx, id, upd)
+	return err
+}
+
+// parseLimit is parseInt for limits that can't be removed once set
+func parseLimit(name, value string) (int64, error) {
+	n, err := parseInt(name, value)
+	if err == nil && n == 0 && strings.TrimSpace(value) != "" {
+		return 0, errNoRemove("the " + name)
+	}
+	return n, err
+}
+
+// parseMemoryLimit is parseMemory for limits that can't be removed once set
+func parseMemoryLimit(name, value string) (int64, error) {
+	n, err := parseMemory(name, value)
+	if err == nil && n == 0 && strings.TrimSpace(value) != "" {
+		return 0, errNoRemove("the " + name)
+	}
+	return n, err
+}
+
+func parseInt(name, value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s: %q", name, value)
+	}
+	return n, nil
+}
+
+func parseMemory(name, value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := units.RAMInBytes(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %q", name, value)
+	}
+	return n, nil
+}
@@ -0,0 +1,26 @@
+package container
+
+import "testing"
+
+func TestLimitsChanges(t *testing.T) {
+	current := Limits{CPUs: "1", Memory: "512m", MemorySwap: "1g", PidsLimit: "100", RestartPolicy: "no"}
+
+	// Raising the memory with the default swap carries the swap along
+	got := Limits{CPUs: "1", Memory: "2g", MemorySwap: "1g", PidsLimit: "100", RestartPolicy: "no"}.Changes(current)
+	want := Limits{Memory: "2g", MemorySwap: "4g"}
+	if got != want {
+		t.Fatalf("Changes() = %+v, want %+v", got, want)
+	}
+
+	// A custom swap is left alone
+	current.MemorySwap = "768m"
+	got = Limits{CPUs: "1", Memory: "2g", MemorySwap: "768m", PidsLimit: "-1", RestartPolicy: "no"}.Changes(current)
+	want = Limits{Memory: "2g", PidsLimit: "-1"}
+	if got != want {
+		t.Fatalf("Changes() = %+v, want %+v", got, want)
+	}
+
+	if got := current.Changes(current); got != (Limits{}) {
+		t.Fatalf("unchanged form: %+v", got)
+	}
+}
@@ -0,0 +1,69 @@
+package container
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// createNetHelper creates (without starting) a container of image sharing
+// the network namespace of the container id, like docker run --network container:id
+func (m *Manager) createNetHelper(id, image string, cmd []string, capAdd ...string) (string, error) {
+	cfg := &container.Config{
+		Image:        image,
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+		Labels:       map[string]string{"d4s.helper": "network"},
+	}
+	hostCfg := &container.HostConfig{
+		NetworkMode: container.NetworkMode("container:" + id),
+		CapAdd:      capAdd,
+	}
+	name := fmt.Sprintf("d4s-net-helper-%d", time.Now().UnixNano())
+	resp, err := m.cli.ContainerCreate(m.ctx, cfg, hostCfg, nil, nil, name)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+func (m *Manager) removeHelper(id string) {
+	m.cli.ContainerRemove(m.ctx, id, container.RemoveOptions{Force: true})
+}
+
+// runNetHelper runs cmd in a network helper of the container id and returns
+// its combined output, whatever its exit code.
+func (m *Manager) runNetHelper(id, image string, cmd []string, timeout time.Duration) (string, error) {
+	helperID, err := m.createNetHelper(id, image, cmd)
+	if err != nil {
+		return "", err
+	}
+	defer m.removeHelper(helperID)
+
+	if err := m.cli.ContainerStart(m.ctx, helperID, container.StartOptions{}); err != nil {
+		return "", err
+	}
+	statusCh, errCh := m.cli.ContainerWait(m.ctx, helperID, container.WaitConditionNotRunning)
+	select {
+	case err := <-errCh:
+		return "", err
+	case <-statusCh:
+	case <-time.After(timeout):
+		return "", fmt.Errorf("helper did not finish within %s", timeout)
+	}
+
+	logs, err := m.cli.ContainerLogs(m.ctx, helperID, container.LogsOptions{ShowStdout: true, ShowStderr: true})
+	if err != nil {
+		return "", err
+	}
+	defer logs.Close()
+	var out bytes.Buffer
+	if _, err := stdcopy.StdCopy(&out, &out, logs); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,564 @@
+package container
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-units"
+	"github.com/jr-k/d4s/internal/dao/common"
+)
+
+// RunSpec describes a container to create, mirroring the common `docker run` flags.
+// Limits are kept as typed by the user ("1.5", "512m") and parsed on create.
+type RunSpec struct {
+	Name          string
+	Image         string
+	Cmd           []string
+	Env           []string // KEY=VALUE
+	Ports         []string // [ip:]hostPort:containerPort[/proto]
+	Volumes       []string // volume:/path[:ro] or /host/path:/path[:ro]
+	Networks      []string
+	RestartPolicy string // no, always, unless-stopped, on-failure[:max]
+	Labels        []string // key=value
+	CPUs          string
+	Memory        string
+	AutoRemove    bool
+}
+
+// Configs converts the spec into the API create payloads. Only the first network
+// is attached at creation time, the others are connected afterwards.
+func (s RunSpec) Configs() (*container.Config, *container.HostConfig, *network.NetworkingConfig, error) {
+	cfg := &container.Config{}
+	hostCfg := &container.HostConfig{}
+	if err := s.applyTo(cfg, hostCfg); err != nil {
+		return nil, nil, nil, err
+	}
+
+	var netCfg *network.NetworkingConfig
+	if len(s.Networks) > 0 {
+		netCfg = &network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				s.Networks[0]: {},
+			},
+		}
+	}
+	return cfg, hostCfg, netCfg, nil
+}
+
+// applyTo overrides the settings covered by the spec, keeping everything else
+// from cfg/hostCfg (used to recreate a container from its inspect data).
+func (s RunSpec) applyTo(cfg *container.Config, hostCfg *container.HostConfig) error {
+	if strings.TrimSpace(s.Image) == "" {
+		return fmt.Errorf("image is required")
+	}
+
+	exposed, bindings, err := nat.ParsePortSpecs(s.Ports)
+	if err != nil {
+		return fmt.Errorf("invalid port: %w", err)
+	}
+
+	labels := make(map[string]string, len(s.Labels))
+	for _, l := range s.Labels {
+		k, v, _ := strings.Cut(l, "=")
+		if k = strings.TrimSpace(k); k == "" {
+			return fmt.Errorf("invalid label: %q", l)
+		}
+		labels[k] = v
+	}
+
+	restart, err := parseRestartPolicy(s.RestartPolicy)
+	if err != nil {
+		return err
+	}
+	if s.AutoRemove && restart.Name != container.RestartPolicyDisabled {
+		return fmt.Errorf("auto-remove conflicts with restart policy %q", restart.Name)
+	}
+
+	var nanoCPUs, memory int64
+	if s.CPUs != "" {
+		cpus, err := strconv.ParseFloat(s.CPUs, 64)
+		if err != nil || cpus <= 0 {
+			return fmt.Errorf("invalid cpus: %q", s.CPUs)
+		}
+		nanoCPUs = int64(cpus * 1e9)
+	}
+	if s.Memory != "" {
+		mem, err := units.RAMInBytes(s.Memory)
+		if err != nil {
+			return fmt.Errorf("invalid memory: %q", s.Memory)
+		}
+		memory = mem
+	}
+
+	// Keep ports exposed by the image, add the published ones
+	for p := range cfg.ExposedPorts {
+		exposed[p] = struct{}{}
+	}
+
+	cfg.Image = s.Image
+	cfg.Cmd = s.Cmd
+	cfg.Env = s.Env
+	cfg.Labels = labels
+	cfg.ExposedPorts = exposed
+
+	hostCfg.Binds = s.Volumes
+	hostCfg.PortBindings = bindings
+	hostCfg.RestartPolicy = restart
+	hostCfg.AutoRemove = s.AutoRemove
+	hostCfg.NanoCPUs = nanoCPUs
+	hostCfg.Memory = memory
+	if len(s.Networks) > 0 {
+		hostCfg.NetworkMode = container.NetworkMode(s.Networks[0])
+	}
+
+	return nil
+}
+
+func parseRestartPolicy(policy string) (container.RestartPolicy, error) {
+	policy = strings.TrimSpace(policy)
+	if policy == "" {
+		return container.RestartPolicy{Name: container.RestartPolicyDisabled}, nil
+	}
+
+	name, max, hasMax := strings.Cut(policy, ":")
+	rp := container.RestartPolicy{Name: container.RestartPolicyMode(name)}
+	if hasMax {
+		n, err := strconv.Atoi(max)
+		if err != nil || n < 0 {
+			return rp, fmt.Errorf("invalid restart retries: %q", max)
+		}
+		rp.MaximumRetryCount = n
+	}
+	if err := container.ValidateRestartPolicy(rp); err != nil {
+		return rp, err
+	}
+	return rp, nil
+}
+
+// DockerRunCommand renders the equivalent `docker run` command line.
+func (s RunSpec) DockerRunCommand() string {
+	args := []string{"docker", "run", "-d"}
+	if s.Name != "" {
+		args = append(args, "--name", s.Name)
+	}
+	if s.AutoRemove {
+		args = append(args, "--rm")
+	}
+	if s.RestartPolicy != "" && s.RestartPolicy != "no" {
+		args = append(args, "--restart", s.RestartPolicy)
+	}
+	for _, e := range s.Env {
+		args = append(args, "-e", e)
+	}
+	for _, p := range s.Ports {
+		args = append(args, "-p", p)
+	}
+	for _, v := range s.Volumes {
+		args = append(args, "-v", v)
+	}
+	for _, n := range s.Networks {
+		args = append(args, "--network", n)
+	}
+	labels := append([]string(nil), s.Labels...)
+	sort.Strings(labels)
+	for _, l := range labels {
+		args = append(args, "--label", l)
+	}
+	if s.CPUs != "" {
+		args = append(args, "--cpus", s.CPUs)
+	}
+	if s.Memory != "" {
+		args = append(args, "--memory", s.Memory)
+	}
+	args = append(args, s.Image)
+	args = append(args, s.Cmd...)
+	return common.JoinCommandLine(args)
+}
+
+// Create creates (without starting) a container from the spec and returns its ID.
+func (m *Manager) Create(spec RunSpec) (string, error) {
+	return m.create(spec, nil, false)
+}
+
+// create builds the container on top of base (inspect data of the container
+// being cloned) when provided, so settings not covered by RunSpec are kept.
+// Static addresses are only kept when replacing the base container.
+func (m *Manager) create(spec RunSpec, base *container.InspectResponse, keepAddresses bool) (string, error) {
+	cfg := &container.Config{}
+	hostCfg := &container.HostConfig{}
+	endpoints := make(map[string]*network.EndpointSettings)
+
+	if base != nil && base.ContainerJSONBase != nil {
+		if base.Config != nil {
+			c := *base.Config
+			cfg = &c
+			// Hostname defaults to the short ID, let the daemon pick a new one
+			if strings.HasPrefix(base.ID, cfg.Hostname) {
+				cfg.Hostname = ""
+			}
+			// Entrypoint, working dir... follow the image when it changes
+			m.imageDefaults(base.Image).strip(cfg)
+		}
+		if base.HostConfig != nil {
+			h := *base.HostConfig
+			hostCfg = &h
+			// Volume/bind mounts are carried by spec.Volumes, keep the others (tmpfs, ...)
+			var mounts []mount.Mount
+			for _, mnt := range h.Mounts {
+				if mnt.Type != mount.TypeBind && mnt.Type != mount.TypeVolume {
+					mounts = append(mounts, mnt)
+				}
+			}
+			hostCfg.Mounts = mounts
+		}
+		if base.NetworkSettings != nil {
+			for name, ep := range base.NetworkSettings.Networks {
+				if ep == nil {
+					continue
+				}
+				var aliases []string
+				for _, a := range ep.Aliases {
+					if !strings.HasPrefix(base.ID, a) {
+						aliases = append(aliases, a)
+					}
+				}
+				endpoints[name] = &network.EndpointSettings{
+					Aliases:    aliases,
+					Links:      ep.Links,
+					DriverOpts: ep.DriverOpts,
+				}
+				if keepAddresses {
+					endpoints[name].IPAMConfig = ep.IPAMConfig
+				}
+			}
+		}
+	}
+
+	if err := spec.applyTo(cfg, hostCfg); err != nil {
+		return "", err
+	}
+
+	var netCfg *network.NetworkingConfig
+	if len(spec.Networks) > 0 {
+		ep := endpoints[spec.Networks[0]]
+		if ep == nil {
+			ep = &network.EndpointSettings{}
+		}
+		netCfg = &network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{spec.Networks[0]: ep},
+		}
+	}
+
+	resp, err := m.cli.ContainerCreate(m.ctx, cfg, hostCfg, netCfg, nil, spec.Name)
+	if err != nil {
+		return "", err
+	}
+
+	for _, n := range spec.Networks[min(1, len(spec.Networks)):] {
+		if err := m.cli.NetworkConnect(m.ctx, n, resp.ID, endpoints[n]); err != nil {
+			_ = m.cli.ContainerRemove(m.ctx, resp.ID, container.RemoveOptions{Force: true})
+			return "", fmt.Errorf("connect %s: %w", n, err)
+		}
+	}
+
+	return resp.ID, nil
+}
+
+// Spec builds a RunSpec pre-filled from an existing container's inspect data.
+func (m *Manager) Spec(id string) (RunSpec, error) {
+	info, err := m.cli.ContainerInspect(m.ctx, id)
+	if err != nil {
+		return RunSpec{}, err
+	}
+	if info.Config != nil {
+		c := *info.Config
+		m.imageDefaults(info.Image).strip(&c)
+		info.Config = &c
+	}
+	return specFromInspect(info), nil
+}
+
+// imageDefaults is the configuration a container inherits from its image
+type imageDefaults struct {
+	Entrypoint []string
+	Cmd        []string
+	Env        []string
+	WorkingDir string
+	Labels     map[string]string
+}
+
+// imageDefaults returns the config of the image id, nil when it is gone.
+func (m *Manager) imageDefaults(id string) *imageDefaults {
+	img, err := m.cli.ImageInspect(m.ctx, id)
+	if err != nil || img.Config == nil {
+		return nil
+	}
+	return &imageDefaults{
+		Entrypoint: img.Config.Entrypoint,
+		Cmd:        img.Config.Cmd,
+		Env:        img.Config.Env,
+		WorkingDir: img.Config.WorkingDir,
+		Labels:     img.Config.Labels,
+	}
+}
+
+// strip drops from cfg the values inherited from the image, so a container
+// recreated on another image gets the defaults of the new one.
+func (d *imageDefaults) strip(cfg *container.Config) {
+	if d == nil {
+		return
+	}
+	if slices.Equal(cfg.Entrypoint, d.Entrypoint) {
+		cfg.Entrypoint = nil
+	}
+	if slices.Equal(cfg.Cmd, d.Cmd) {
+		cfg.Cmd = nil
+	}
+	if cfg.WorkingDir == d.WorkingDir {
+		cfg.WorkingDir = ""
+	}
+
+	var env []string
+	for _, e := range cfg.Env {
+		if !slices.Contains(d.Env, e) {
+			env = append(env, e)
+		}
+	}
+	cfg.Env = env
+
+	labels := make(map[string]string)
+	for k, v := range cfg.Labels {
+		if value, ok := d.Labels[k]; !ok || value != v {
+			labels[k] = v
+		}
+	}
+	cfg.Labels = labels
+}
+
+// ClearHostPorts keeps the container side of port specs and lets the daemon
+// pick the host ports ("8080:80" becomes "80"), e.g. for a clone of a running
+// container that publishes them.
+func ClearHostPorts(ports []string) []string {
+	var res []string
+	for _, raw := range ports {
+		mappings, err := nat.ParsePortSpec(raw)
+		if err != nil {
+			res = append(res, raw)
+			continue
+		}
+		for _, pm := range mappings {
+			entry := pm.Port.Port()
+			if pm.Port.Proto() != "tcp" {
+				entry += "/" + pm.Port.Proto()
+			}
+			if ip := pm.Binding.HostIP; ip != "" {
+				if strings.Contains(ip, ":") {
+					ip = "[" + ip + "]"
+				}
+				entry = ip + "::" + entry
+			}
+			if !slices.Contains(res, entry) {
+				res = append(res, entry)
+			}
+		}
+	}
+	return res
+}
+
+// withoutComposeLabels drops the com.docker.compose.* labels from key=value labels
+func withoutComposeLabels(labels []string) []string {
+	var out []string
+	for _, l := range labels {
+		if !strings.HasPrefix(l, "com.docker.compose.") {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+func specFromInspect(info container.InspectResponse) RunSpec {
+	spec := RunSpec{}
+	if info.ContainerJSONBase != nil {
+		spec.Name = strings.TrimPrefix(info.Name, "/")
+	}
+
+	if info.Config != nil {
+		spec.Image = info.Config.Image
+		spec.Cmd = info.Config.Cmd
+		spec.Env = info.Config.Env
+		for k, v := range info.Config.Labels {
+			spec.Labels = append(spec.Labels, k+"="+v)
+		}
+		sort.Strings(spec.Labels)
+	}
+
+	if info.ContainerJSONBase != nil && info.HostConfig != nil {
+		hc := info.HostConfig
+		for port, bindings := range hc.PortBindings {
+			for _, b := range bindings {
+				entry := port.Port()
+				if b.HostPort != "" || b.HostIP != "" {
+					entry = b.HostPort + ":" + entry
+				}
+				if b.HostIP != "" {
+					entry = b.HostIP + ":" + entry
+				}
+				if port.Proto() != "tcp" {
+					entry += "/" + port.Proto()
+				}
+				spec.Ports = append(spec.Ports, entry)
+			}
+		}
+		sort.Strings(spec.Ports)
+
+		rp := string(hc.RestartPolicy.Name)
+		if hc.RestartPolicy.Name == container.RestartPolicyOnFailure && hc.RestartPolicy.MaximumRetryCount > 0 {
+			rp = fmt.Sprintf("%s:%d", rp, hc.RestartPolicy.MaximumRetryCount)
+		}
+		if rp != string(container.RestartPolicyDisabled) {
+			spec.RestartPolicy = rp
+		}
+
+		if hc.NanoCPUs > 0 {
+			spec.CPUs = strconv.FormatFloat(float64(hc.NanoCPUs)/1e9, 'f', -1, 64)
+		}
+		if hc.Memory > 0 {
+			spec.Memory = formatMemory(hc.Memory)
+		}
+		spec.AutoRemove = hc.AutoRemove
+	}
+
+	for _, mnt := range info.Mounts {
+		source := mnt.Source
+		if mnt.Type == mount.TypeVolume {
+			source = mnt.Name
+		} else if mnt.Type != mount.TypeBind {
+			continue
+		}
+		entry := source + ":" + mnt.Destination
+		if !mnt.RW {
+			entry += ":ro"
+		}
+		spec.Volumes = append(spec.Volumes, entry)
+	}
+
+	if info.NetworkSettings != nil {
+		for name := range info.NetworkSettings.Networks {
+			spec.Networks = append(spec.Networks, name)
+		}
+		sort.Strings(spec.Networks)
+		// Keep the primary network (NetworkMode) first
+		if info.ContainerJSONBase != nil && info.HostConfig != nil {
+			primary := string(info.HostConfig.NetworkMode)
+			for i, n := range spec.Networks {
+				if n == primary {
+					spec.Networks[0], spec.Networks[i] = spec.Networks[i], spec.Networks[0]
+					break
+				}
+			}
+		}
+	}
+
+	return spec
+}
+
+// formatMemory renders a byte count with the largest exact unit (512m, 2g).
+func formatMemory(b int64) string {
+	for _, u := range []struct {
+		suffix string
+		size   int64
+	}{{"g", units.GiB}, {"m", units.MiB}, {"k", units.KiB}} {
+		if b%u.size == 0 {
+			return fmt.Sprintf("%d%s", b/u.size, u.suffix)
+		}
+	}
+	return fmt.Sprintf("%db", b)
+}
+
+// Recreate replaces a container with one built from spec. When the name is
+// unchanged the old container is stopped and renamed aside, then removed once
+// the new one is started; on failure it is renamed back and restarted.
+// With a different name the container is cloned and the original left
+// untouched; the clone drops the compose labels so it is not taken for a
+// replica of the original's service.
+func (m *Manager) Recreate(id string, spec RunSpec, start bool) (string, error) {
+	info, err := m.cli.ContainerInspect(m.ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if info.ContainerJSONBase == nil || info.State == nil {
+		return "", fmt.Errorf("incomplete inspect data for %s", id)
+	}
+
+	oldName := strings.TrimPrefix(info.Name, "/")
+	if spec.Name != "" && spec.Name != oldName {
+		spec.Labels = withoutComposeLabels(spec.Labels)
+		newID, err := m.create(spec, &info, false)
+		if err != nil {
+			return "", err
+		}
+		if start {
+			if err := m.Start(newID); err != nil {
+				_ = m.Remove(newID, true)
+				return "", err
+			}
+		}
+		return newID, nil
+	}
+
+	if info.HostConfig != nil && info.HostConfig.AutoRemove {
+		return "", fmt.Errorf("%s is auto-removed on stop, clone it under a new name instead", oldName)
+	}
+
+	wasRunning := info.State.Running || info.State.Paused
+	if wasRunning {
+		if err := m.Stop(info.ID); err != nil {
+			return "", err
+		}
+	}
+
+	backup := fmt.Sprintf("%s-d4s-old-%d", oldName, time.Now().Unix())
+	if err := m.cli.ContainerRename(m.ctx, info.ID, backup); err != nil {
+		if wasRunning {
+			_ = m.Start(info.ID)
+		}
+		return "", err
+	}
+
+	rollback := func(cause error) error {
+		if err := m.cli.ContainerRename(m.ctx, info.ID, oldName); err != nil {
+			return fmt.Errorf("%v (rollback failed, old container kept as %s: %v)", cause, backup, err)
+		}
+		if wasRunning {
+			if err := m.Start(info.ID); err != nil {
+				return fmt.Errorf("%v (rolled back but old container failed to start: %v)", cause, err)
+			}
+		}
+		return fmt.Errorf("%v (rolled back)", cause)
+	}
+
+	spec.Name = oldName
+	newID, err := m.create(spec, &info, true)
+	if err != nil {
+		return "", rollback(err)
+	}
+	if start {
+		if err := m.Start(newID); err != nil {
+			_ = m.Remove(newID, true)
+			return "", rollback(err)
+		}
+	}
+
+	if err := m.Remove(info.ID, true); err != nil {
+		return newID, fmt.Errorf("recreated, but old container %s not removed: %v", backup, err)
+	}
+	return newID, nil
+}
@@ -0,0 +1,54 @@
+package container
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestImageDefaultsStrip(t *testing.T) {
+	defaults := &imageDefaults{
+		Entrypoint: []string{"/entrypoint.sh"},
+		Cmd:        []string{"serve"},
+		Env:        []string{"PATH=/usr/bin", "APP_VERSION=1.0"},
+		WorkingDir: "/app",
+		Labels:     map[string]string{"org.opencontainers.image.version": "1.0"},
+	}
+	cfg := &container.Config{
+		Entrypoint: []string{"/entrypoint.sh"},
+		Cmd:        []string{"serve", "--debug"},
+		Env:        []string{"PATH=/usr/bin", "APP_VERSION=1.0", "TOKEN=x"},
+		WorkingDir: "/app",
+		Labels:     map[string]string{"org.opencontainers.image.version": "1.0", "team": "web"},
+	}
+	defaults.strip(cfg)
+
+	if cfg.Entrypoint != nil || cfg.WorkingDir != "" {
+		t.Fatalf("image entrypoint/workdir kept: %v %q", cfg.Entrypoint, cfg.WorkingDir)
+	}
+	if !slices.Equal(cfg.Cmd, []string{"serve", "--debug"}) {
+		t.Fatalf("custom cmd dropped: %v", cfg.Cmd)
+	}
+	if !slices.Equal(cfg.Env, []string{"TOKEN=x"}) {
+		t.Fatalf("env = %v", cfg.Env)
+	}
+	if len(cfg.Labels) != 1 || cfg.Labels["team"] != "web" {
+		t.Fatalf("labels = %v", cfg.Labels)
+	}
+}
+
+func TestClearHostPorts(t *testing.T) {
+	got := ClearHostPorts([]string{"8080:80", "127.0.0.1:5353:53/udp", "9000"})
+	want := []string{"80", "127.0.0.1::53/udp", "9000"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("ClearHostPorts() = %v, want %v", got, want)
+	}
+}
+
+func TestWithoutComposeLabels(t *testing.T) {
+	got := withoutComposeLabels([]string{"com.docker.compose.project=app", "team=web", "com.docker.compose.service=db"})
+	if !slices.Equal(got, []string{"team=web"}) {
+		t.Fatalf("withoutComposeLabels() = %v", got)
+	}
+}
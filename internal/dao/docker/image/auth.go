@@ -0,0 +1,90 @@
+package image
+
+import (
+	"log"
+	"os"
+
+	"github.com/distribution/reference"
+	"github.com/docker/cli/cli/config"
+	"github.com/docker/docker/api/types/registry"
+)
+
+// Docker Hub credentials are stored under the legacy index address
+const dockerHubAuthKey = "https://index.docker.io/v1/"
+
+// RegistryHost returns the registry hostname of an image reference (docker.io for Hub images).
+func RegistryHost(ref string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", err
+	}
+	return reference.Domain(named), nil
+}
+
+// Credentials resolves the credentials for the registry hosting ref from the
+// Docker CLI config file and its credential helpers (credsStore/credHelpers).
+// An empty AuthConfig is returned for anonymous access.
+func Credentials(ref string) (registry.AuthConfig, error) {
+	host, err := RegistryHost(ref)
+	if err != nil {
+		return registry.AuthConfig{}, err
+	}
+	key := host
+	if host == "docker.io" {
+		key = dockerHubAuthKey
+	}
+
+	cfg, err := config.Load(config.Dir())
+	if err != nil {
+		return registry.AuthConfig{}, err
+	}
+	ac, err := cfg.GetAuthConfig(key)
+	if err != nil {
+		return registry.AuthConfig{}, err
+	}
+
+	return registry.AuthConfig{
+		Username:      ac.Username,
+		Password:      ac.Password,
+		Auth:          ac.Auth,
+		ServerAddress: ac.ServerAddress,
+		IdentityToken: ac.IdentityToken,
+		RegistryToken: ac.RegistryToken,
+	}, nil
+}
+
+// credentialsOrAnonymous is Credentials falling back to anonymous access
+// when the config or a credential helper fails (missing docker-credential-*
+// binary, locked keychain...), as the docker CLI does. Public images still work.
+func credentialsOrAnonymous(ref string) registry.AuthConfig {
+	ac, err := Credentials(ref)
+	if err != nil {
+		logAuthError(ref, err)
+		return registry.AuthConfig{}
+	}
+	return ac
+}
+
+// logAuthError records a failed credential lookup in the debug log, the TUI owns the terminal
+func logAuthError(ref string, err error) {
+	f, ferr := os.OpenFile("/tmp/d4s_debug_dao.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if ferr != nil {
+		return
+	}
+	defer f.Close()
+	log.New(f, "d4s-dao: ", log.LstdFlags).Printf("credentials for %s: %v, using anonymous access", ref, err)
+}
+
+// EncodedAuth returns the X-Registry-Auth header value for ref ("" when anonymous).
+func EncodedAuth(ref string) string {
+	ac := credentialsOrAnonymous(ref)
+	if ac.Username == "" && ac.Password == "" && ac.IdentityToken == "" && ac.RegistryToken == "" {
+		return ""
+	}
+	auth, err := registry.EncodeAuthConfig(ac)
+	if err != nil {
+		logAuthError(ref, err)
+		return ""
+	}
+	return auth
+}
@@ -0,0 +1,183 @@
+package image
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jr-k/d4s/internal/dao/common"
+)
+
+// At most this many output lines are kept per build
+const buildLogLimit = 5000
+
+// BuildOptions are the settings of a plain image build (docker build)
+type BuildOptions struct {
+	ContextDir string
+	Dockerfile string   // Relative to the context directory, empty for its Dockerfile
+	Tags       []string // repo:tag
+	BuildArgs  []string // KEY=VALUE
+	Target     string
+	Platform   string
+}
+
+// Args returns the docker CLI arguments of the build, writing the image ID to iidFile.
+func (o BuildOptions) Args(iidFile string) ([]string, error) {
+	dir := common.ExpandPath(strings.TrimSpace(o.ContextDir))
+	if dir == "" {
+		return nil, fmt.Errorf("build context directory is required")
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("build context %q is not a directory", dir)
+	}
+
+	args := []string{"build", "--progress=plain"}
+	if iidFile != "" {
+		args = append(args, "--iidfile", iidFile)
+	}
+	if f := strings.TrimSpace(o.Dockerfile); f != "" {
+		f = common.ExpandPath(f)
+		if !filepath.IsAbs(f) {
+			f = filepath.Join(dir, f)
+		}
+		args = append(args, "-f", f)
+	}
+	for _, t := range o.Tags {
+		args = append(args, "-t", t)
+	}
+	for _, a := range o.BuildArgs {
+		if !strings.Contains(a, "=") {
+			return nil, fmt.Errorf("invalid build arg %q (KEY=VALUE)", a)
+		}
+		args = append(args, "--build-arg", a)
+	}
+	if o.Target != "" {
+		args = append(args, "--target", o.Target)
+	}
+	if o.Platform != "" {
+		args = append(args, "--platform", o.Platform)
+	}
+	return append(args, dir), nil
+}
+
+// BuildLog collects the output of a running build
+type BuildLog struct {
+	mu      sync.Mutex
+	lines   []string
+	dropped int
+	done    bool
+	err     error
+	imageID string
+	started time.Time
+}
+
+func NewBuildLog() *BuildLog {
+	return &BuildLog{started: time.Now()}
+}
+
+func (l *BuildLog) append(line string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, line)
+	if len(l.lines) > buildLogLimit {
+		l.dropped += len(l.lines) - buildLogLimit
+		l.lines = l.lines[len(l.lines)-buildLogLimit:]
+	}
+}
+
+func (l *BuildLog) finish(imageID string, err error) {
+	l.mu.Lock()
+	l.imageID, l.err, l.done = imageID, err, true
+	l.mu.Unlock()
+}
+
+// Snapshot returns the kept lines, the number of dropped ones and the build state.
+func (l *BuildLog) Snapshot() (lines []string, dropped int, done bool, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.lines...), l.dropped, l.done, l.err
+}
+
+// ImageID returns the built image ID once the build succeeded.
+func (l *BuildLog) ImageID() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.imageID
+}
+
+func (l *BuildLog) Elapsed() time.Duration {
+	return time.Since(l.started)
+}
+
+// ErrBuildCancelled is returned when the build context is cancelled
+var ErrBuildCancelled = errors.New("build cancelled")
+
+// Build runs docker build through the given CLI factory (pinned to the
+// client's context) and streams its BuildKit progress into log. Cancelling
+// ctx interrupts the docker CLI, which stops the build on the daemon.
+func Build(ctx context.Context, dockerCmd func(ctx context.Context, args ...string) *exec.Cmd, opts BuildOptions, log *BuildLog) (string, error) {
+	iid, err := os.CreateTemp("", "d4s-build-*.iid")
+	if err != nil {
+		log.finish("", err)
+		return "", err
+	}
+	iid.Close()
+	defer os.Remove(iid.Name())
+
+	args, err := opts.Args(iid.Name())
+	if err != nil {
+		log.finish("", err)
+		return "", err
+	}
+
+	cmd := dockerCmd(ctx, args...)
+	cmd.Env = append(os.Environ(), "DOCKER_BUILDKIT=1")
+	// Interrupt like ctrl-c so the CLI cancels the BuildKit session, kill it if it hangs
+	cmd.Cancel = func() error { return cmd.Process.Signal(os.Interrupt) }
+	cmd.WaitDelay = 10 * time.Second
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	if err := cmd.Start(); err != nil {
+		log.finish("", err)
+		return "", err
+	}
+	go func() {
+		pw.CloseWithError(cmd.Wait())
+	}()
+
+	scanner := bufio.NewScanner(pr)
+	scanner.Buffer(make([]byte, 64*1024), 8*1024*1024)
+	for scanner.Scan() {
+		log.append(scanner.Text())
+	}
+	err = scanner.Err()
+	if errors.Is(err, bufio.ErrTooLong) {
+		// Keep draining so the CLI never blocks on the pipe, the build result is its exit status
+		log.append("(output line too long, rest of the output skipped)")
+		_, err = io.Copy(io.Discard, pr)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			err = ErrBuildCancelled
+		} else {
+			err = fmt.Errorf("build failed: %v", err)
+		}
+		log.finish("", err)
+		return "", err
+	}
+
+	data, _ := os.ReadFile(iid.Name())
+	imageID := strings.TrimPrefix(strings.TrimSpace(string(data)), "sha256:")
+	log.finish(imageID, nil)
+	return imageID, nil
+}
@@ -0,0 +1,115 @@
+package image
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Layer is one entry of an image history, from the base layer upwards
+type Layer struct {
+	ID         string
+	Created    time.Time
+	CreatedBy  string // Creating instruction (e.g. "RUN apt-get update")
+	Comment    string
+	Size       int64
+	Cumulative int64 // Image size up to and including this layer
+	Empty      bool  // Metadata-only instruction (ENV, CMD, ...)
+}
+
+// Details is the native layer/config view of an image (what dive shows, without dive)
+type Details struct {
+	ID           string
+	RepoTags     []string
+	Architecture string
+	Os           string
+	Variant      string
+	Size         int64
+	Created      string
+	User         string
+	WorkingDir   string
+	Entrypoint   []string
+	Cmd          []string
+	Env          []string
+	ExposedPorts []string
+	Labels       map[string]string
+	Layers       []Layer
+}
+
+// Details returns the layer history and config summary of an image.
+func (m *Manager) Details(id string) (*Details, error) {
+	info, err := m.cli.ImageInspect(m.ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	history, err := m.cli.ImageHistory(m.ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Details{
+		ID:           info.ID,
+		RepoTags:     info.RepoTags,
+		Architecture: info.Architecture,
+		Os:           info.Os,
+		Variant:      info.Variant,
+		Size:         info.Size,
+		Created:      info.Created,
+	}
+	if cfg := info.Config; cfg != nil {
+		d.User = cfg.User
+		d.WorkingDir = cfg.WorkingDir
+		d.Entrypoint = cfg.Entrypoint
+		d.Cmd = cfg.Cmd
+		d.Env = cfg.Env
+		d.Labels = cfg.Labels
+		for port := range cfg.ExposedPorts {
+			d.ExposedPorts = append(d.ExposedPorts, string(port))
+		}
+		sort.Strings(d.ExposedPorts)
+	}
+
+	// The API returns the most recent layer first
+	var cumulative int64
+	for i := len(history) - 1; i >= 0; i-- {
+		h := history[i]
+		cumulative += h.Size
+		id := h.ID
+		if id == "<missing>" {
+			id = ""
+		}
+		d.Layers = append(d.Layers, Layer{
+			ID:         id,
+			Created:    time.Unix(h.Created, 0),
+			CreatedBy:  Instruction(h.CreatedBy),
+			Comment:    h.Comment,
+			Size:       h.Size,
+			Cumulative: cumulative,
+			Empty:      h.Size == 0,
+		})
+	}
+
+	return d, nil
+}
+
+// Instruction turns a history CreatedBy into a Dockerfile-like instruction,
+// for both the legacy builder ("/bin/sh -c #(nop) CMD ...") and BuildKit.
+func Instruction(createdBy string) string {
+	s := strings.TrimSpace(createdBy)
+	s = strings.TrimSuffix(s, "# buildkit")
+	s = strings.TrimSpace(s)
+
+	if rest, ok := strings.CutPrefix(s, "/bin/sh -c #(nop)"); ok {
+		return strings.TrimSpace(rest)
+	}
+	if rest, ok := strings.CutPrefix(s, "/bin/sh -c "); ok {
+		return "RUN " + strings.TrimSpace(rest)
+	}
+	if rest, ok := strings.CutPrefix(s, "|"); ok {
+		// Legacy RUN with build args: "|2 ARG=x ARG2=y /bin/sh -c cmd"
+		if _, cmd, found := strings.Cut(rest, "/bin/sh -c "); found {
+			return "RUN " + strings.TrimSpace(cmd)
+		}
+	}
+	return strings.Join(strings.Fields(s), " ")
+}
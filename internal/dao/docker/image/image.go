@@ -2,13 +2,13 @@ package image
 
 import (
 	"fmt"
-	"io"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/client"
 	"github.com/gdamore/tcell/v2"
 	"github.com/jr-k/d4s/internal/dao/common"
@@ -21,6 +21,13 @@ type Manager struct {
 	ctx context.Context
 	
 	pullStatuses map[string]string
+	pulls        map[string]*Progress // Latest pull progress per reference
+	pushes       map[string]*Progress // Latest push progress per reference
+	remoteTags   map[string]remoteTagsEntry // Registry tag listings per repository
+	updates      updateChecks               // Registry digest checks per tagged reference
+	scans        map[string]*ScanReport     // Latest vulnerability scan per image ID
+	scanning     map[string]bool            // Image IDs being scanned
+	registries   *registry.ServiceConfig    // Daemon registry configuration, fetched on first use
 	statusMu     sync.RWMutex
 }
 
@@ -29,6 +36,8 @@ func NewManager(cli *client.Client, ctx context.Context) *Manager {
 		cli:          cli,
 		ctx:          ctx,
 		pullStatuses: make(map[string]string),
+		pulls:        make(map[string]*Progress),
+		pushes:       make(map[string]*Progress),
 	}
 }
 
@@ -40,6 +49,8 @@ type Image struct {
 	Size       string
 	Created    string
 	Containers int64
+	Vulns      string // Severity summary of the latest scan
+	Pulling    bool   // A pull or push of the tag is running
 }
 
 func (i Image) GetID() string { return i.ID }
@@ -48,11 +59,11 @@ func (i Image) GetCells() []string {
 	if i.Containers <= 0 {
 		containersStr = ""
 	}
-	return []string{i.ID[:12], i.Tags, i.Size, containersStr, i.Created}
+	return []string{i.ID[:12], i.Tags, i.Size, containersStr, i.Created, i.Vulns}
 }
 
 func (i Image) GetStatusColor() (tcell.Color, tcell.Color) {
-	if strings.Contains(i.Tags, "Pulling") {
+	if i.Pulling {
 		return styles.ColorStatusOrange, styles.ColorBlack
 	}
 	return styles.ColorIdle, styles.ColorBlack
@@ -70,6 +81,8 @@ func (i Image) GetColumnValue(column string) string {
 		return fmt.Sprintf("%d", i.Containers)
 	case "created":
 		return i.Created
+	case "vulns":
+		return i.Vulns
 	}
 	return ""
 }
@@ -110,19 +123,72 @@ func (m *Manager) GetPullStatus(tag string) string {
 	if m.pullStatuses == nil {
 		return ""
 	}
+
+	// Live progress while the pull is running
+	if p, ok := m.pulls[tag]; ok {
+		if _, _, done, _ := p.Snapshot(); !done {
+			return fmt.Sprintf(" [%s]⟳ %s[-]", styles.ColorStatusOrange.String(), p.Summary())
+		}
+	}
+	if p, ok := m.pushes[tag]; ok {
+		if _, _, done, _ := p.Snapshot(); !done {
+			return fmt.Sprintf(" [%s]⟳ push %s[-]", styles.ColorStatusOrange.String(), p.Summary())
+		}
+	}
 	
 	return m.pullStatuses[tag]
 }
 
+// IsPulling reports whether a pull or push of tag is running.
+func (m *Manager) IsPulling(tag string) bool {
+	for _, p := range []*Progress{m.GetPullProgress(tag), m.GetPushProgress(tag)} {
+		if p != nil {
+			if _, _, done, _ := p.Snapshot(); !done {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetPullProgress returns the progress of the latest pull of ref (nil if none).
+func (m *Manager) GetPullProgress(ref string) *Progress {
+	if m == nil {
+		return nil
+	}
+	m.statusMu.RLock()
+	defer m.statusMu.RUnlock()
+	return m.pulls[ref]
+}
+
+// StartPull registers a new progress for ref, so it can be watched before the pull begins.
+func (m *Manager) StartPull(ref string) *Progress {
+	p := NewProgress(ref)
+	m.statusMu.Lock()
+	if m.pulls == nil {
+		m.pulls = make(map[string]*Progress)
+	}
+	m.pulls[ref] = p
+	m.statusMu.Unlock()
+	return p
+}
+
 func (m *Manager) Pull(tag string) error {
 	if m == nil || m.cli == nil {
 		return fmt.Errorf("image manager not initialized")
 	}
 
 	m.SetPullStatus(tag, fmt.Sprintf(" [%s]⟳ Pulling...[-]", styles.ColorStatusOrange.String()))
-	
-	reader, err := m.cli.ImagePull(m.ctx, tag, image.PullOptions{})
-	if err != nil {
+
+	progress := m.GetPullProgress(tag)
+	if progress == nil {
+		progress = m.StartPull(tag)
+	} else if _, _, done, _ := progress.Snapshot(); done {
+		progress = m.StartPull(tag)
+	}
+
+	fail := func(err error) error {
+		progress.Finish(err)
 		m.SetPullStatus(tag, fmt.Sprintf(" [%s]✘ Error[-]", styles.TagError))
 		go func() {
 			time.Sleep(5 * time.Second)
@@ -130,10 +196,19 @@ func (m *Manager) Pull(tag string) error {
 		}()
 		return err
 	}
+
+	// Credentials from the Docker CLI config (private registries)
+	auth := EncodedAuth(tag)
+
+	reader, err := m.cli.ImagePull(m.ctx, tag, image.PullOptions{RegistryAuth: auth})
+	if err != nil {
+		return fail(err)
+	}
 	defer reader.Close()
 
-	// Consume output to wait for finish
-	_, _ = io.Copy(io.Discard, reader)
+	if err := progress.Consume(reader); err != nil {
+		return fail(err)
+	}
 
 	m.SetPullStatus(tag, fmt.Sprintf(" [%s]✔ Done[-]", styles.ColorSelect.String()))
 	go func() {
@@ -174,13 +249,16 @@ func (m *Manager) List() ([]common.Resource, error) {
 				tags += status
 			}
 		}
+		id := strings.TrimPrefix(i.ID, "sha256:")
 		res = append(res, Image{
-			ID:         strings.TrimPrefix(i.ID, "sha256:"),
+			ID:         id,
 			RepoTag:    rawTag,
 			Tags:       tags,
 			Size:       common.FormatBytes(i.Size),
 			Created:    common.FormatTime(i.Created),
 			Containers: i.Containers,
+			Vulns:      m.scanStatus(id),
+			Pulling:    rawTag != "" && m.IsPulling(rawTag),
 		})
 	}
 	return res, nil
@@ -0,0 +1,207 @@
+package image
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/jr-k/d4s/internal/dao/common"
+)
+
+// LayerProgress is the state of one layer in a pull/push stream
+type LayerProgress struct {
+	ID      string
+	Status  string
+	Current int64
+	Total   int64
+}
+
+// Done reports whether the layer transfer is finished
+func (l LayerProgress) Done() bool {
+	switch l.Status {
+	case "Pull complete", "Already exists", "Download complete", "Pushed", "Layer already exists":
+		return true
+	}
+	if l.Status == "Loading layer" {
+		return l.Total > 0 && l.Current >= l.Total
+	}
+	return strings.HasPrefix(l.Status, "Mounted from")
+}
+
+// Progress aggregates a Docker JSON progress stream (pull, push, load)
+type Progress struct {
+	Ref     string
+	Started time.Time
+
+	mu     sync.RWMutex
+	layers map[string]*LayerProgress
+	order  []string
+	status []string // Global (non-layer) messages
+	done   bool
+	err    error
+	ended  time.Time
+}
+
+func NewProgress(ref string) *Progress {
+	return &Progress{
+		Ref:     ref,
+		Started: time.Now(),
+		layers:  make(map[string]*LayerProgress),
+	}
+}
+
+// Consume reads the stream until EOF or an error message, then marks the progress done.
+func (p *Progress) Consume(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	var err error
+	for {
+		var msg jsonmessage.JSONMessage
+		if decErr := dec.Decode(&msg); decErr != nil {
+			if !errors.Is(decErr, io.EOF) {
+				err = decErr
+			}
+			break
+		}
+		if msg.Error != nil {
+			err = errors.New(msg.Error.Message)
+			break
+		}
+		if msg.ErrorMessage != "" {
+			err = errors.New(msg.ErrorMessage)
+			break
+		}
+		p.apply(msg)
+	}
+	p.Finish(err)
+	return err
+}
+
+func (p *Progress) apply(msg jsonmessage.JSONMessage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Plain output lines (e.g. "Loaded image: nginx:latest" from a load)
+	if msg.Stream != "" {
+		if line := strings.TrimSpace(msg.Stream); line != "" {
+			p.status = append(p.status, line)
+		}
+		return
+	}
+
+	// Layer messages carry a short digest ID, global ones do not (or carry the tag)
+	if msg.ID == "" || (msg.Progress == nil && !isLayerStatus(msg.Status)) {
+		if msg.Status != "" {
+			line := msg.Status
+			if msg.ID != "" {
+				line = msg.ID + ": " + line
+			}
+			p.status = append(p.status, line)
+		}
+		return
+	}
+
+	layer, ok := p.layers[msg.ID]
+	if !ok {
+		layer = &LayerProgress{ID: msg.ID}
+		p.layers[msg.ID] = layer
+		p.order = append(p.order, msg.ID)
+	}
+	layer.Status = msg.Status
+	if msg.Progress != nil {
+		// Extraction reports its own current/total, keep download totals
+		if msg.Status != "Extracting" || layer.Total == 0 {
+			layer.Current = msg.Progress.Current
+			if msg.Progress.Total > 0 {
+				layer.Total = msg.Progress.Total
+			}
+		}
+	}
+}
+
+func isLayerStatus(status string) bool {
+	switch status {
+	case "Pulling fs layer", "Waiting", "Downloading", "Verifying Checksum", "Download complete",
+		"Extracting", "Pull complete", "Already exists", "Preparing", "Pushing", "Pushed",
+		"Layer already exists", "Loading layer":
+		return true
+	}
+	return strings.HasPrefix(status, "Mounted from") || strings.HasPrefix(status, "Retrying")
+}
+
+// Finish marks the progress as ended (err is nil on success).
+func (p *Progress) Finish(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done {
+		return
+	}
+	p.done = true
+	p.err = err
+	p.ended = time.Now()
+}
+
+// Snapshot returns a copy of the current state.
+func (p *Progress) Snapshot() (layers []LayerProgress, status []string, done bool, err error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	layers = make([]LayerProgress, 0, len(p.order))
+	for _, id := range p.order {
+		layers = append(layers, *p.layers[id])
+	}
+	return layers, append([]string(nil), p.status...), p.done, p.err
+}
+
+// Elapsed returns the time spent so far (or in total once done).
+func (p *Progress) Elapsed() time.Duration {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.done {
+		return p.ended.Sub(p.Started)
+	}
+	return time.Since(p.Started)
+}
+
+// Bytes returns transferred and total bytes over the layers of known size.
+func (p *Progress) Bytes() (current, total int64) {
+	layers, _, _, _ := p.Snapshot()
+	for _, l := range layers {
+		if l.Total <= 0 {
+			continue
+		}
+		total += l.Total
+		if l.Done() || l.Status == "Extracting" {
+			current += l.Total
+		} else {
+			current += min(l.Current, l.Total)
+		}
+	}
+	return current, total
+}
+
+// Summary returns a short one-line state (e.g. "42% 12.3 MiB/29.1 MiB 3/7").
+func (p *Progress) Summary() string {
+	layers, _, done, err := p.Snapshot()
+	if err != nil {
+		return "error"
+	}
+	finished := 0
+	for _, l := range layers {
+		if l.Done() {
+			finished++
+		}
+	}
+	if done {
+		return fmt.Sprintf("done %d/%d", finished, len(layers))
+	}
+
+	current, total := p.Bytes()
+	if total == 0 {
+		return fmt.Sprintf("%d/%d", finished, len(layers))
+	}
+	return fmt.Sprintf("%d%% %s/%s %d/%d", current*100/total, common.FormatBytes(current), common.FormatBytes(total), finished, len(layers))
+}
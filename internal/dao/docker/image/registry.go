@@ -0,0 +1,671 @@
+package image
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/gdamore/tcell/v2"
+	"github.com/jr-k/d4s/internal/dao/common"
+	"github.com/jr-k/d4s/internal/ui/styles"
+)
+
+const (
+	// Registry listings are slow, keep them around between refreshes
+	remoteTagsTTL = 5 * time.Minute
+	// At most this many tags are listed (each one costs registry round-trips)
+	remoteTagsDetailLimit = 100
+	remoteTagsConcurrency = 8
+)
+
+// Manifest media types accepted from registries (indexes first)
+var manifestAccept = strings.Join([]string{mediaTypeOCIIndex, mediaTypeDockerManifestList, mediaTypeOCIManifest, mediaTypeDockerManifest}, ", ")
+
+const (
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeOCIManifest        = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeOCIIndex           = "application/vnd.oci.image.index.v1+json"
+)
+
+// RemoteTag is a tag available in the registry of an image repository
+type RemoteTag struct {
+	Repository string
+	Name       string
+	Digest     string
+	Size       int64
+	Pushed     time.Time
+	Platforms  []string
+}
+
+func (t RemoteTag) GetID() string { return t.Name }
+func (t RemoteTag) GetCells() []string {
+	return []string{t.Name, t.shortDigest(), t.size(), t.pushed(), strings.Join(t.Platforms, ", ")}
+}
+
+// Ref returns the pullable reference (repository:tag)
+func (t RemoteTag) Ref() string {
+	return t.Repository + ":" + t.Name
+}
+
+func (t RemoteTag) shortDigest() string {
+	if len(t.Digest) > 19 {
+		return t.Digest[:19]
+	}
+	return t.Digest
+}
+
+func (t RemoteTag) size() string {
+	if t.Size <= 0 {
+		return ""
+	}
+	return common.FormatBytes(t.Size)
+}
+
+func (t RemoteTag) pushed() string {
+	if t.Pushed.IsZero() {
+		return ""
+	}
+	return common.FormatTime(t.Pushed.Unix())
+}
+
+func (t RemoteTag) GetStatusColor() (tcell.Color, tcell.Color) {
+	return styles.ColorIdle, styles.ColorBlack
+}
+
+func (t RemoteTag) GetColumnValue(column string) string {
+	switch strings.ToLower(column) {
+	case "tag":
+		return t.Name
+	case "digest":
+		return t.Digest
+	case "size":
+		return t.size()
+	case "pushed":
+		return t.pushed()
+	case "platforms":
+		return strings.Join(t.Platforms, ", ")
+	}
+	return ""
+}
+
+func (t RemoteTag) GetDefaultColumn() string {
+	return "Tag"
+}
+
+func (t RemoteTag) GetDefaultSortColumn() string {
+	return "Pushed"
+}
+
+type remoteTagsEntry struct {
+	at   time.Time
+	tags []common.Resource
+}
+
+// RepositoryName returns the repository part of a reference (without tag or digest).
+func RepositoryName(ref string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", err
+	}
+	return reference.FamiliarName(named), nil
+}
+
+// RemoteTags lists the tags of a repository in its registry (Docker Hub or
+// any registry v2), using the Docker CLI credentials. Results are cached.
+func (m *Manager) RemoteTags(repo string, refresh bool) ([]common.Resource, error) {
+	m.statusMu.RLock()
+	entry, ok := m.remoteTags[repo]
+	m.statusMu.RUnlock()
+	if ok && !refresh && time.Since(entry.at) < remoteTagsTTL {
+		return entry.tags, nil
+	}
+
+	named, err := reference.ParseNormalizedNamed(repo)
+	if err != nil {
+		return nil, err
+	}
+	auth := credentialsOrAnonymous(repo)
+
+	var tags []RemoteTag
+	if reference.Domain(named) == "docker.io" {
+		tags, err = hubTags(reference.Path(named), auth)
+	} else {
+		host := reference.Domain(named)
+		rc := newRegistryClient(host, reference.Path(named), auth, m.insecureRegistry(host))
+		tags, err = rc.tags()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]common.Resource, len(tags))
+	for i, t := range tags {
+		t.Repository = repo
+		res[i] = t
+	}
+
+	m.statusMu.Lock()
+	if m.remoteTags == nil {
+		m.remoteTags = make(map[string]remoteTagsEntry)
+	}
+	m.remoteTags[repo] = remoteTagsEntry{at: time.Now(), tags: res}
+	m.statusMu.Unlock()
+	return res, nil
+}
+
+var registryHTTP = &http.Client{Timeout: 20 * time.Second}
+
+// Docker Hub: the hub API returns size, digest and push date in one listing
+
+type hubTagsPage struct {
+	Next    string `json:"next"`
+	Results []struct {
+		Name        string    `json:"name"`
+		FullSize    int64     `json:"full_size"`
+		Digest      string    `json:"digest"`
+		LastUpdated time.Time `json:"last_updated"`
+		TagLastPush time.Time `json:"tag_last_pushed"`
+		Images      []struct {
+			Architecture string `json:"architecture"`
+			Variant      string `json:"variant"`
+			OS           string `json:"os"`
+		} `json:"images"`
+	} `json:"results"`
+}
+
+func hubTags(path string, auth registry.AuthConfig) ([]RemoteTag, error) {
+	token := ""
+	if auth.Username != "" && auth.Password != "" {
+		token, _ = hubLogin(auth.Username, auth.Password) // Fall back to anonymous access
+	}
+
+	next := fmt.Sprintf("https://hub.docker.com/v2/repositories/%s/tags?page_size=100&ordering=last_updated", path)
+	var tags []RemoteTag
+	for next != "" && len(tags) < remoteTagsDetailLimit {
+		req, err := http.NewRequest(http.MethodGet, next, nil)
+		if err != nil {
+			return nil, err
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := registryHTTP.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		var page hubTagsPage
+		err = decodeResponse(resp, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range page.Results {
+			t := RemoteTag{Name: r.Name, Digest: r.Digest, Size: r.FullSize, Pushed: r.TagLastPush}
+			if t.Pushed.IsZero() {
+				t.Pushed = r.LastUpdated
+			}
+			for _, img := range r.Images {
+				t.Platforms = append(t.Platforms, platformString(img.OS, img.Architecture, img.Variant))
+			}
+			tags = append(tags, t)
+		}
+		next = page.Next
+	}
+	return tags, nil
+}
+
+func hubLogin(username, password string) (string, error) {
+	body, _ := json.Marshal(map[string]string{"username": username, "password": password})
+	resp, err := registryHTTP.Post("https://hub.docker.com/v2/users/login", "application/json", strings.NewReader(string(body)))
+	if err != nil {
+		return "", err
+	}
+	var out struct {
+		Token string `json:"token"`
+	}
+	if err := decodeResponse(resp, &out); err != nil {
+		return "", err
+	}
+	return out.Token, nil
+}
+
+// Registry v2 API
+
+type registryClient struct {
+	host     string
+	scheme   string
+	insecure bool // Plain HTTP is allowed when the registry does not speak TLS
+	repo     string
+	auth     registry.AuthConfig
+	token    string // Bearer token, or "basic" once a Basic challenge was answered
+	mu       sync.Mutex
+}
+
+// registryClientFor returns a client for the repository of named, using
+// the Docker CLI credentials of its registry.
+func (m *Manager) registryClientFor(named reference.Named) *registryClient {
+	auth := credentialsOrAnonymous(named.String())
+	host := reference.Domain(named)
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+	return newRegistryClient(host, reference.Path(named), auth, m.insecureRegistry(host))
+}
+
+func newRegistryClient(host, repo string, auth registry.AuthConfig, insecure bool) *registryClient {
+	return &registryClient{host: host, scheme: "https", insecure: insecure, repo: repo, auth: auth}
+}
+
+// insecureRegistry reports whether a registry may be reached over plain HTTP:
+// loopback hosts and the insecure registries configured on the daemon.
+func (m *Manager) insecureRegistry(host string) bool {
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+	ip := net.ParseIP(hostname)
+	if hostname == "localhost" || (ip != nil && ip.IsLoopback()) {
+		return true
+	}
+
+	cfg := m.registryConfig()
+	if cfg == nil {
+		return false
+	}
+	if index, ok := cfg.IndexConfigs[host]; ok && !index.Secure {
+		return true
+	}
+	if ip != nil {
+		for _, cidr := range cfg.InsecureRegistryCIDRs {
+			if (*net.IPNet)(cidr).Contains(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// registryConfig returns the daemon registry configuration, fetched once
+func (m *Manager) registryConfig() *registry.ServiceConfig {
+	m.statusMu.RLock()
+	cfg := m.registries
+	m.statusMu.RUnlock()
+	if cfg != nil {
+		return cfg
+	}
+
+	info, err := m.cli.Info(m.ctx)
+	if err != nil || info.RegistryConfig == nil {
+		return nil
+	}
+	m.statusMu.Lock()
+	m.registries = info.RegistryConfig
+	m.statusMu.Unlock()
+	return info.RegistryConfig
+}
+
+func (c *registryClient) tags() ([]RemoteTag, error) {
+	var names []string
+	next := fmt.Sprintf("/v2/%s/tags/list", c.repo)
+	for next != "" {
+		var list struct {
+			Tags []string `json:"tags"`
+		}
+		resp, err := c.get(next, "")
+		if err != nil {
+			return nil, err
+		}
+		next = nextLink(resp.Header.Get("Link"))
+		if err := decodeResponse(resp, &list); err != nil {
+			return nil, err
+		}
+		names = append(names, list.Tags...)
+	}
+
+	// Registries list tags lexically (1.10 before 1.9), order them by version
+	// and resolve the last ones: the highest versions, not named tags like alpine
+	sort.SliceStable(names, func(i, j int) bool { return compareTags(names[i], names[j]) < 0 })
+	if len(names) > remoteTagsDetailLimit {
+		names = names[len(names)-remoteTagsDetailLimit:]
+	}
+
+	tags := make([]RemoteTag, len(names))
+	sem := make(chan struct{}, remoteTagsConcurrency)
+	var wg sync.WaitGroup
+	for i, name := range names {
+		tags[i] = RemoteTag{Name: name}
+		wg.Add(1)
+		go func(t *RemoteTag) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			c.resolve(t) // Best effort, the tag stays listed without details
+		}(&tags[i])
+	}
+	wg.Wait()
+	return tags, nil
+}
+
+// compareTags orders tag names by version: names that are not versions
+// (latest, alpine...) first, then versions by their numeric segments, so that
+// 1.9 < v1.10 < 1.10-alpine < 2.0.
+func compareTags(a, b string) int {
+	if va, vb := isVersionTag(a), isVersionTag(b); va != vb {
+		if va {
+			return 1
+		}
+		return -1
+	} else if va {
+		a, b = strings.TrimPrefix(a, "v"), strings.TrimPrefix(b, "v")
+	}
+	for a != "" && b != "" {
+		ca, ra := tagSegment(a)
+		cb, rb := tagSegment(b)
+		if c := compareSegments(ca, cb); c != 0 {
+			return c
+		}
+		a, b = ra, rb
+	}
+	return len(a) - len(b)
+}
+
+func isVersionTag(tag string) bool {
+	tag = strings.TrimPrefix(tag, "v")
+	return tag != "" && tag[0] >= '0' && tag[0] <= '9'
+}
+
+// tagSegment splits the leading run of digits or non-digits from s
+func tagSegment(s string) (string, string) {
+	digit := s[0] >= '0' && s[0] <= '9'
+	i := 1
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9') == digit {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func compareSegments(a, b string) int {
+	numA := a[0] >= '0' && a[0] <= '9'
+	numB := b[0] >= '0' && b[0] <= '9'
+	if numA && numB {
+		a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+		if len(a) != len(b) {
+			return len(a) - len(b)
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+type manifest struct {
+	MediaType string `json:"mediaType"`
+	Config    struct {
+		Digest string `json:"digest"`
+		Size   int64  `json:"size"`
+	} `json:"config"`
+	Layers []struct {
+		Digest      string            `json:"digest"`
+		Size        int64             `json:"size"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"layers"`
+	Manifests []struct {
+		Digest      string            `json:"digest"`
+		Annotations map[string]string `json:"annotations"`
+		Platform    struct {
+			Architecture string `json:"architecture"`
+			OS           string `json:"os"`
+			Variant      string `json:"variant"`
+		} `json:"platform"`
+	} `json:"manifests"`
+}
+
+// resolve fills digest, size, push date and platforms of a tag
+func (c *registryClient) resolve(t *RemoteTag) error {
+	m, digest, err := c.manifest(t.Name, manifestAccept)
+	if err != nil {
+		return err
+	}
+	t.Digest = digest
+
+	if len(m.Manifests) > 0 {
+		// Multi-platform: describe the image of this machine's architecture
+		chosen := m.Manifests[0].Digest
+		for _, entry := range m.Manifests {
+			p := entry.Platform
+			if p.OS == "unknown" || p.Architecture == "unknown" {
+				continue // Attestation manifests
+			}
+			t.Platforms = append(t.Platforms, platformString(p.OS, p.Architecture, p.Variant))
+			if p.OS == "linux" && p.Architecture == runtime.GOARCH {
+				chosen = entry.Digest
+			}
+		}
+		if m, _, err = c.manifest(chosen, manifestAccept); err != nil {
+			return err
+		}
+	}
+
+	for _, l := range m.Layers {
+		t.Size += l.Size
+	}
+
+	if m.Config.Digest == "" {
+		return nil
+	}
+	var cfg struct {
+		Created      time.Time `json:"created"`
+		Architecture string    `json:"architecture"`
+		OS           string    `json:"os"`
+		Variant      string    `json:"variant"`
+	}
+	resp, err := c.get(fmt.Sprintf("/v2/%s/blobs/%s", c.repo, m.Config.Digest), "")
+	if err != nil {
+		return err
+	}
+	if err := decodeResponse(resp, &cfg); err != nil {
+		return err
+	}
+	t.Pushed = cfg.Created
+	if len(t.Platforms) == 0 && cfg.OS != "" {
+		t.Platforms = []string{platformString(cfg.OS, cfg.Architecture, cfg.Variant)}
+	}
+	return nil
+}
+
+func (c *registryClient) manifest(ref, accept string) (manifest, string, error) {
+	var m manifest
+	resp, err := c.get(fmt.Sprintf("/v2/%s/manifests/%s", c.repo, ref), accept)
+	if err != nil {
+		return m, "", err
+	}
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if err := decodeResponse(resp, &m); err != nil {
+		return m, "", err
+	}
+	if digest == "" && strings.HasPrefix(ref, "sha256:") {
+		digest = ref
+	}
+	return m, digest, nil
+}
+
+// get performs an authenticated GET, answering the registry auth challenge once
+func (c *registryClient) get(path, accept string) (*http.Response, error) {
+	return c.request(http.MethodGet, path, accept)
+}
+
+func (c *registryClient) request(method, path, accept string) (*http.Response, error) {
+	resp, err := c.do(method, path, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+	if err := c.authenticate(challenge); err != nil {
+		return nil, err
+	}
+	return c.do(method, path, accept)
+}
+
+func (c *registryClient) do(method, path, accept string) (*http.Response, error) {
+	c.mu.Lock()
+	scheme, token := c.scheme, c.token
+	c.mu.Unlock()
+
+	req, err := http.NewRequest(method, scheme+"://"+c.host+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	switch {
+	case token == "basic":
+		req.SetBasicAuth(c.auth.Username, c.auth.Password)
+	case token != "":
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := registryHTTP.Do(req)
+	if err != nil && scheme == "https" && c.insecure && strings.Contains(err.Error(), "server gave HTTP response to HTTPS client") {
+		// Plain HTTP registry, allowed for loopback and daemon insecure registries only
+		c.mu.Lock()
+		c.scheme = "http"
+		c.mu.Unlock()
+		return c.do(method, path, accept)
+	}
+	return resp, err
+}
+
+// nextLink returns the request path of the rel="next" entry of a Link header
+// (registry pagination), or "" on the last page.
+func nextLink(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		target, params, _ := strings.Cut(strings.TrimSpace(link), ";")
+		if !strings.Contains(strings.ReplaceAll(params, " ", ""), `rel="next"`) {
+			continue
+		}
+		u, err := url.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+		if err != nil {
+			return ""
+		}
+		return u.RequestURI()
+	}
+	return ""
+}
+
+func (c *registryClient) authenticate(challenge string) error {
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.auth.Username == "" {
+			return errors.New("registry requires credentials (docker login)")
+		}
+		c.mu.Lock()
+		c.token = "basic"
+		c.mu.Unlock()
+		return nil
+	case "bearer":
+	default:
+		return fmt.Errorf("unsupported registry auth challenge: %q", challenge)
+	}
+
+	if c.auth.RegistryToken != "" {
+		c.mu.Lock()
+		c.token = c.auth.RegistryToken
+		c.mu.Unlock()
+		return nil
+	}
+
+	q := url.Values{}
+	if params["service"] != "" {
+		q.Set("service", params["service"])
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", c.repo)
+	}
+	q.Set("scope", scope)
+
+	req, err := http.NewRequest(http.MethodGet, params["realm"]+"?"+q.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if c.auth.Username != "" && c.auth.Password != "" {
+		req.SetBasicAuth(c.auth.Username, c.auth.Password)
+	}
+	resp, err := registryHTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	var out struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := decodeResponse(resp, &out); err != nil {
+		return fmt.Errorf("registry token: %w", err)
+	}
+
+	c.mu.Lock()
+	c.token = out.Token
+	if c.token == "" {
+		c.token = out.AccessToken
+	}
+	c.mu.Unlock()
+	return nil
+}
+
+// parseChallenge parses `Bearer realm="...",service="...",scope="..."`
+func parseChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := make(map[string]string)
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(rest, "=")
+		key = strings.ToLower(strings.TrimSpace(strings.TrimLeft(key, ", ")))
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key != "" {
+			params[key] = value
+		}
+	}
+	return scheme, params
+}
+
+func decodeResponse(resp *http.Response, out interface{}) error {
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		msg := strings.TrimSpace(string(body))
+		if msg == "" {
+			msg = resp.Status
+		}
+		return fmt.Errorf("%s: %s", resp.Request.URL.Host, msg)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func platformString(os, arch, variant string) string {
+	p := os + "/" + arch
+	if variant != "" {
+		p += "/" + variant
+	}
+	return p
+}
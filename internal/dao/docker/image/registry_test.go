@@ -0,0 +1,16 @@
+package image
+
+import (
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestCompareTags(t *testing.T) {
+	tags := []string{"latest", "1.10", "alpine", "1.9", "2.0", "1.10-alpine", "v1.2"}
+	sort.SliceStable(tags, func(i, j int) bool { return compareTags(tags[i], tags[j]) < 0 })
+	want := []string{"alpine", "latest", "v1.2", "1.9", "1.10", "1.10-alpine", "2.0"}
+	if !slices.Equal(tags, want) {
+		t.Fatalf("sorted tags = %v, want %v", tags, want)
+	}
+}
@@ -0,0 +1,278 @@
+package image
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/distribution/reference"
+)
+
+// SBOM export formats
+const (
+	SBOMFormatSPDX      = "spdx"
+	SBOMFormatCycloneDX = "cyclonedx"
+)
+
+const predicateTypeSPDX = "https://spdx.dev/Document"
+
+// SBOMOptions selects how syft runs when the image has no SBOM attestation
+type SBOMOptions struct {
+	Mode   string // auto (default), binary or container
+	Image  string // Syft image in container mode (default: anchore/syft:latest)
+	Socket string // Daemon socket mounted in container mode (default: /var/run/docker.sock)
+	NoRun  bool   // Refuse container mode (read-only mode)
+}
+
+// Package is one entry of a software bill of materials
+type Package struct {
+	Name     string
+	Version  string
+	Type     string
+	Licenses string
+	PURL     string
+}
+
+// SBOM is the software bill of materials of an image
+type SBOM struct {
+	Target    string
+	Source    string // "attestation" or "syft"
+	CreatedAt time.Time
+	Packages  []Package
+	spdx      []byte // Original SPDX JSON document
+}
+
+// SBOM returns the bill of materials of an image: the BuildKit SBOM
+// attestation published with it when there is one, otherwise a syft scan.
+func (m *Manager) SBOM(id, target string, opts SBOMOptions, contextName, sshHost string) (*SBOM, error) {
+	doc, err := m.attestedSBOM(id, target)
+	source := "attestation"
+	if err != nil || doc == nil {
+		if doc, err = runSyft(target, opts, contextName, sshHost); err != nil {
+			return nil, err
+		}
+		source = "syft"
+	}
+
+	packages, err := parseSPDX(doc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SPDX document: %w", err)
+	}
+	return &SBOM{Target: target, Source: source, CreatedAt: time.Now(), Packages: packages, spdx: doc}, nil
+}
+
+// attestedSBOM fetches the SPDX attestation BuildKit attached to the image
+// index in its registry (nil when the image has none)
+func (m *Manager) attestedSBOM(id, target string) ([]byte, error) {
+	named, err := reference.ParseNormalizedNamed(target)
+	if err != nil {
+		return nil, err
+	}
+	info, err := m.cli.ImageInspect(m.ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The index digest the image was pulled from
+	indexDigest := ""
+	for _, rd := range info.RepoDigests {
+		if c, err := reference.ParseNormalizedNamed(rd); err == nil && c.Name() == named.Name() {
+			if d, ok := c.(reference.Canonical); ok {
+				indexDigest = d.Digest().String()
+				break
+			}
+		}
+	}
+	if indexDigest == "" {
+		return nil, nil
+	}
+
+	rc := m.registryClientFor(named)
+	index, _, err := rc.manifest(indexDigest, manifestAccept)
+	if err != nil {
+		return nil, err
+	}
+
+	imageDigest := ""
+	for _, entry := range index.Manifests {
+		if entry.Platform.OS == info.Os && entry.Platform.Architecture == info.Architecture {
+			imageDigest = entry.Digest
+			break
+		}
+	}
+	for _, entry := range index.Manifests {
+		if entry.Annotations["vnd.docker.reference.type"] != "attestation-manifest" ||
+			entry.Annotations["vnd.docker.reference.digest"] != imageDigest {
+			continue
+		}
+		attestation, _, err := rc.manifest(entry.Digest, manifestAccept)
+		if err != nil {
+			return nil, err
+		}
+		for _, layer := range attestation.Layers {
+			if layer.Annotations["in-toto.io/predicate-type"] != predicateTypeSPDX {
+				continue
+			}
+			var statement struct {
+				Predicate json.RawMessage `json:"predicate"`
+			}
+			resp, err := rc.get(fmt.Sprintf("/v2/%s/blobs/%s", rc.repo, layer.Digest), "")
+			if err != nil {
+				return nil, err
+			}
+			if err := decodeResponse(resp, &statement); err != nil {
+				return nil, err
+			}
+			return statement.Predicate, nil
+		}
+	}
+	return nil, nil
+}
+
+// runSyft scans the image with the syft binary when available (auto) and
+// otherwise its official image
+func runSyft(target string, opts SBOMOptions, contextName, sshHost string) ([]byte, error) {
+	t := helperTool{Name: "syft", Image: opts.Image, Mode: opts.Mode, Config: "sbom", Socket: opts.Socket, NoRun: opts.NoRun}
+	if t.Image == "" {
+		t.Image = "anchore/syft:latest"
+	}
+	cmd, err := t.command([]string{"docker:" + target, "-o", "spdx-json", "-q"}, contextName, sshHost)
+	if err != nil {
+		return nil, err
+	}
+	return runTool("syft", cmd)
+}
+
+type spdxDocument struct {
+	Packages []struct {
+		Name                  string `json:"name"`
+		VersionInfo           string `json:"versionInfo"`
+		LicenseConcluded      string `json:"licenseConcluded"`
+		LicenseDeclared       string `json:"licenseDeclared"`
+		PrimaryPackagePurpose string `json:"primaryPackagePurpose"`
+		ExternalRefs          []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+func parseSPDX(data []byte) ([]Package, error) {
+	var doc spdxDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var packages []Package
+	for _, p := range doc.Packages {
+		// The scanned image and its files are described as packages too
+		if p.PrimaryPackagePurpose == "CONTAINER" || p.PrimaryPackagePurpose == "FILE" || p.Name == "" {
+			continue
+		}
+		pkg := Package{Name: p.Name, Version: p.VersionInfo}
+		for _, l := range []string{p.LicenseDeclared, p.LicenseConcluded} {
+			if l != "" && l != "NOASSERTION" && l != "NONE" {
+				pkg.Licenses = l
+				break
+			}
+		}
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				pkg.PURL = ref.ReferenceLocator
+				// pkg:<type>/<namespace>/<name>@<version>
+				if t, _, ok := strings.Cut(strings.TrimPrefix(pkg.PURL, "pkg:"), "/"); ok {
+					pkg.Type = t
+				}
+				break
+			}
+		}
+		packages = append(packages, pkg)
+	}
+
+	sort.SliceStable(packages, func(i, j int) bool {
+		if packages[i].Name != packages[j].Name {
+			return packages[i].Name < packages[j].Name
+		}
+		return packages[i].Version < packages[j].Version
+	})
+	return packages, nil
+}
+
+// Export writes the SBOM as SPDX or CycloneDX JSON in dir and returns the file path.
+func (s *SBOM) Export(format, dir string) (string, error) {
+	var data []byte
+	var ext string
+	switch format {
+	case SBOMFormatSPDX:
+		data, ext = s.spdx, "spdx.json"
+	case SBOMFormatCycloneDX:
+		var err error
+		if data, err = s.cycloneDX(); err != nil {
+			return "", err
+		}
+		ext = "cdx.json"
+	default:
+		return "", fmt.Errorf("unsupported SBOM format %q", format)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	name := strings.NewReplacer("/", "_", ":", "_", "@", "_").Replace(s.Target)
+	path := filepath.Join(dir, fmt.Sprintf("sbom-%s-%s.%s", name, s.CreatedAt.Format("20060102-150405"), ext))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// cycloneDX converts the package list to a CycloneDX 1.5 document
+func (s *SBOM) cycloneDX() ([]byte, error) {
+	type license struct {
+		Expression string `json:"expression"`
+	}
+	type component struct {
+		Type     string    `json:"type"`
+		BOMRef   string    `json:"bom-ref,omitempty"`
+		Name     string    `json:"name"`
+		Version  string    `json:"version,omitempty"`
+		PURL     string    `json:"purl,omitempty"`
+		Licenses []license `json:"licenses,omitempty"`
+	}
+
+	serial := make([]byte, 16)
+	if _, err := rand.Read(serial); err != nil {
+		return nil, err
+	}
+	serial[6] = serial[6]&0x0f | 0x40 // UUID v4
+	serial[8] = serial[8]&0x3f | 0x80
+
+	components := make([]component, 0, len(s.Packages))
+	for i, p := range s.Packages {
+		c := component{Type: "library", Name: p.Name, Version: p.Version, PURL: p.PURL}
+		c.BOMRef = fmt.Sprintf("pkg-%d", i)
+		if p.Licenses != "" {
+			c.Licenses = []license{{Expression: p.Licenses}}
+		}
+		components = append(components, c)
+	}
+
+	doc := map[string]interface{}{
+		"bomFormat":    "CycloneDX",
+		"specVersion":  "1.5",
+		"serialNumber": fmt.Sprintf("urn:uuid:%x-%x-%x-%x-%x", serial[0:4], serial[4:6], serial[6:8], serial[8:10], serial[10:]),
+		"version":      1,
+		"metadata": map[string]interface{}{
+			"timestamp": s.CreatedAt.UTC().Format(time.RFC3339),
+			"tools":     map[string]interface{}{"components": []component{{Type: "application", Name: "d4s"}}},
+			"component": component{Type: "container", Name: s.Target},
+		},
+		"components": components,
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
@@ -0,0 +1,294 @@
+package image
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jr-k/d4s/internal/ui/styles"
+)
+
+// Supported vulnerability scanners
+const (
+	ScannerTrivy = "trivy"
+	ScannerGrype = "grype"
+)
+
+// Severities from the most to the least urgent
+var Severities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "NEGLIGIBLE", "UNKNOWN"}
+
+// ScanOptions selects the vulnerability scanner and how it runs
+type ScanOptions struct {
+	Tool   string // trivy (default) or grype
+	Mode   string // auto (default), binary or container
+	Image  string // Scanner image in container mode (default: the official one)
+	Socket string // Daemon socket mounted in container mode (default: /var/run/docker.sock)
+	NoRun  bool   // Refuse container mode (read-only mode)
+}
+
+// Vulnerability is one finding of a scan
+type Vulnerability struct {
+	ID        string
+	Severity  string
+	Package   string
+	Type      string
+	Installed string
+	Fixed     string
+	Title     string
+}
+
+// ScanReport is the result of a vulnerability scan of an image
+type ScanReport struct {
+	Tool      string
+	Target    string
+	ScannedAt time.Time
+	Vulns     []Vulnerability
+}
+
+// Counts returns the number of findings per severity.
+func (r *ScanReport) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, v := range r.Vulns {
+		counts[v.Severity]++
+	}
+	return counts
+}
+
+// Summary renders the severity counts for the image list (e.g. "C2 H5 M10 L3").
+func (r *ScanReport) Summary() string {
+	if len(r.Vulns) == 0 {
+		return fmt.Sprintf("[%s]clean[-]", styles.ColorStatusGreen.String())
+	}
+	counts := r.Counts()
+	var parts []string
+	for _, s := range []struct {
+		sev, label, color string
+	}{
+		{"CRITICAL", "C", styles.TagError},
+		{"HIGH", "H", styles.ColorStatusOrange.String()},
+		{"MEDIUM", "M", ""},
+		{"LOW", "L", ""},
+	} {
+		n := counts[s.sev]
+		if n == 0 {
+			continue
+		}
+		if s.color != "" {
+			parts = append(parts, fmt.Sprintf("[%s]%s%d[-]", s.color, s.label, n))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s%d", s.label, n))
+		}
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("%d minor", len(r.Vulns))
+	}
+	return strings.Join(parts, " ")
+}
+
+// GetScanReport returns the latest scan of an image (nil if never scanned).
+func (m *Manager) GetScanReport(id string) *ScanReport {
+	m.statusMu.RLock()
+	defer m.statusMu.RUnlock()
+	return m.scans[strings.TrimPrefix(id, "sha256:")]
+}
+
+// scanStatus is the VULNS cell of an image
+func (m *Manager) scanStatus(id string) string {
+	m.statusMu.RLock()
+	defer m.statusMu.RUnlock()
+	if m.scanning[id] {
+		return fmt.Sprintf("[%s]⟳ scanning[-]", styles.ColorStatusOrange.String())
+	}
+	if r, ok := m.scans[id]; ok {
+		return r.Summary()
+	}
+	return ""
+}
+
+// Scan runs the vulnerability scanner on an image (ID or reference) and
+// keeps the report, keyed by image ID, for the image list.
+func (m *Manager) Scan(id, target string, opts ScanOptions, contextName, sshHost string) (*ScanReport, error) {
+	id = strings.TrimPrefix(id, "sha256:")
+	tool := opts.Tool
+	if tool == "" {
+		tool = ScannerTrivy
+	}
+	if tool != ScannerTrivy && tool != ScannerGrype {
+		return nil, fmt.Errorf("unsupported scanner %q (trivy or grype)", tool)
+	}
+
+	cmd, err := scanCommand(tool, target, opts, contextName, sshHost)
+	if err != nil {
+		return nil, err
+	}
+
+	m.statusMu.Lock()
+	if m.scanning == nil {
+		m.scanning = make(map[string]bool)
+	}
+	m.scanning[id] = true
+	m.statusMu.Unlock()
+	defer func() {
+		m.statusMu.Lock()
+		delete(m.scanning, id)
+		m.statusMu.Unlock()
+	}()
+
+	out, err := runTool(tool, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ScanReport{Tool: tool, Target: target, ScannedAt: time.Now()}
+	if tool == ScannerGrype {
+		report.Vulns, err = parseGrype(out)
+	} else {
+		report.Vulns, err = parseTrivy(out)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s output: %w", tool, err)
+	}
+	sortVulnerabilities(report.Vulns)
+
+	m.statusMu.Lock()
+	if m.scans == nil {
+		m.scans = make(map[string]*ScanReport)
+	}
+	m.scans[id] = report
+	m.statusMu.Unlock()
+	return report, nil
+}
+
+// scanCommand runs the scanner binary when available (auto) and otherwise its
+// official image, keeping the vulnerability database in a volume between runs
+func scanCommand(tool, target string, opts ScanOptions, contextName, sshHost string) (*exec.Cmd, error) {
+	t := helperTool{Name: tool, Image: opts.Image, Mode: opts.Mode, Config: "scanner", Socket: opts.Socket, NoRun: opts.NoRun}
+	var args []string
+	if tool == ScannerGrype {
+		args = []string{"docker:" + target, "-o", "json", "-q"}
+		t.Volumes = []string{"d4s-grype-cache:/root/.cache/grype"}
+		if t.Image == "" {
+			t.Image = "anchore/grype:latest"
+		}
+	} else {
+		args = []string{"image", "--format", "json", "--quiet", "--scanners", "vuln", target}
+		t.Volumes = []string{"d4s-trivy-cache:/root/.cache/trivy"}
+		if t.Image == "" {
+			t.Image = "aquasec/trivy:latest"
+		}
+	}
+	return t.command(args, contextName, sshHost)
+}
+
+func parseTrivy(data []byte) ([]Vulnerability, error) {
+	var out struct {
+		Results []struct {
+			Target          string `json:"Target"`
+			Type            string `json:"Type"`
+			Vulnerabilities []struct {
+				VulnerabilityID  string `json:"VulnerabilityID"`
+				PkgName          string `json:"PkgName"`
+				InstalledVersion string `json:"InstalledVersion"`
+				FixedVersion     string `json:"FixedVersion"`
+				Severity         string `json:"Severity"`
+				Title            string `json:"Title"`
+			} `json:"Vulnerabilities"`
+		} `json:"Results"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	var vulns []Vulnerability
+	for _, r := range out.Results {
+		for _, v := range r.Vulnerabilities {
+			vulns = append(vulns, Vulnerability{
+				ID:        v.VulnerabilityID,
+				Severity:  normalizeSeverity(v.Severity),
+				Package:   v.PkgName,
+				Type:      r.Type,
+				Installed: v.InstalledVersion,
+				Fixed:     v.FixedVersion,
+				Title:     v.Title,
+			})
+		}
+	}
+	return vulns, nil
+}
+
+func parseGrype(data []byte) ([]Vulnerability, error) {
+	var out struct {
+		Matches []struct {
+			Vulnerability struct {
+				ID          string `json:"id"`
+				Severity    string `json:"severity"`
+				Description string `json:"description"`
+				Fix         struct {
+					Versions []string `json:"versions"`
+				} `json:"fix"`
+			} `json:"vulnerability"`
+			Artifact struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+				Type    string `json:"type"`
+			} `json:"artifact"`
+		} `json:"matches"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	vulns := make([]Vulnerability, 0, len(out.Matches))
+	for _, m := range out.Matches {
+		title := m.Vulnerability.Description
+		if i := strings.IndexAny(title, ".\n"); i > 0 {
+			title = title[:i]
+		}
+		vulns = append(vulns, Vulnerability{
+			ID:        m.Vulnerability.ID,
+			Severity:  normalizeSeverity(m.Vulnerability.Severity),
+			Package:   m.Artifact.Name,
+			Type:      m.Artifact.Type,
+			Installed: m.Artifact.Version,
+			Fixed:     strings.Join(m.Vulnerability.Fix.Versions, ", "),
+			Title:     title,
+		})
+	}
+	return vulns, nil
+}
+
+func normalizeSeverity(s string) string {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	for _, known := range Severities {
+		if s == known {
+			return s
+		}
+	}
+	return "UNKNOWN"
+}
+
+func severityRank(s string) int {
+	for i, known := range Severities {
+		if s == known {
+			return i
+		}
+	}
+	return len(Severities)
+}
+
+// sortVulnerabilities orders findings by severity, then package and ID
+func sortVulnerabilities(vulns []Vulnerability) {
+	sort.SliceStable(vulns, func(i, j int) bool {
+		a, b := vulns[i], vulns[j]
+		if ra, rb := severityRank(a.Severity), severityRank(b.Severity); ra != rb {
+			return ra < rb
+		}
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+		return a.ID < b.ID
+	})
+}
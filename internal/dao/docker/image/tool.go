@@ -0,0 +1,83 @@
+package image
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// helperTool is a CLI tool (scanner, syft) run from its local binary or from
+// its image with the daemon socket mounted, so it reads the local images
+type helperTool struct {
+	Name    string   // Binary name
+	Image   string   // Image in container mode
+	Mode    string   // auto (default), binary or container
+	Config  string   // Config section named in errors (e.g. scanner)
+	Socket  string   // Daemon socket on the daemon host (default: /var/run/docker.sock)
+	Volumes []string // Extra mounts in container mode
+	NoRun   bool     // Read-only mode: refuse to start a container
+}
+
+// command returns the command running the tool with args against the daemon
+// of contextName. In auto mode the local binary is preferred, except for SSH
+// contexts since it can't reach the images of a remote daemon reliably.
+func (t helperTool) command(args []string, contextName, sshHost string) (*exec.Cmd, error) {
+	mode := t.Mode
+	if mode == "" || mode == "auto" {
+		mode = "container"
+		if _, err := exec.LookPath(t.Name); err == nil && sshHost == "" {
+			mode = "binary"
+		}
+	}
+
+	switch mode {
+	case "binary":
+		path, err := exec.LookPath(t.Name)
+		if err != nil {
+			return nil, fmt.Errorf("%s not found in PATH (set %s.mode to container)", t.Name, t.Config)
+		}
+		cmd := exec.Command(path, args...)
+		if contextName != "" && contextName != "default" {
+			cmd.Env = append(os.Environ(), "DOCKER_CONTEXT="+contextName)
+			if sshHost != "" {
+				cmd.Env = append(cmd.Env, "DOCKER_HOST=ssh://"+sshHost)
+			}
+		}
+		return cmd, nil
+	case "container":
+		if t.NoRun {
+			return nil, fmt.Errorf("read-only mode: %s would run in a container (install %s locally or set %s.mode to binary)", t.Name, t.Name, t.Config)
+		}
+		socket := t.Socket
+		if socket == "" {
+			socket = "/var/run/docker.sock"
+		}
+		runArgs := []string{"run", "--rm", "-v", socket + ":/var/run/docker.sock"}
+		for _, v := range t.Volumes {
+			runArgs = append(runArgs, "-v", v)
+		}
+		runArgs = append(runArgs, t.Image)
+		if contextName != "" && contextName != "default" {
+			runArgs = append([]string{"--context", contextName}, runArgs...)
+		}
+		return exec.Command("docker", append(runArgs, args...)...), nil
+	}
+	return nil, fmt.Errorf("unsupported %s mode %q (auto, binary or container)", t.Config, t.Mode)
+}
+
+// runTool returns the output of cmd, with the end of its stderr on failure
+func runTool(name string, cmd *exec.Cmd) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if len(msg) > 512 {
+			msg = msg[len(msg)-512:]
+		}
+		return nil, fmt.Errorf("%s failed: %v %s", name, err, msg)
+	}
+	return out, nil
+}
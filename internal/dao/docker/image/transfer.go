@@ -0,0 +1,180 @@
+package image
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/client"
+	"github.com/jr-k/d4s/internal/ui/styles"
+)
+
+// Tag adds the target reference (repo:tag) to the source image.
+func (m *Manager) Tag(source, target string) error {
+	return m.cli.ImageTag(m.ctx, source, target)
+}
+
+// WithRepositoryPrefix moves ref under another registry/namespace, keeping the
+// image name and tag (e.g. "nginx:1.27" + "registry.local:5000/mirror" gives
+// "registry.local:5000/mirror/nginx:1.27").
+func WithRepositoryPrefix(ref, prefix string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", err
+	}
+	path := reference.Path(named)
+	if reference.Domain(named) == "docker.io" {
+		path = strings.TrimPrefix(path, "library/")
+	}
+	target := strings.TrimSuffix(strings.TrimSpace(prefix), "/") + "/" + path
+	if tagged, ok := reference.TagNameOnly(named).(reference.Tagged); ok {
+		target += ":" + tagged.Tag()
+	}
+	if _, err := reference.ParseNormalizedNamed(target); err != nil {
+		return "", fmt.Errorf("invalid target %q: %w", target, err)
+	}
+	return target, nil
+}
+
+// GetPushProgress returns the progress of the latest push of ref (nil if none).
+func (m *Manager) GetPushProgress(ref string) *Progress {
+	if m == nil {
+		return nil
+	}
+	m.statusMu.RLock()
+	defer m.statusMu.RUnlock()
+	return m.pushes[ref]
+}
+
+// StartPush registers a new progress for ref, so it can be watched before the push begins.
+func (m *Manager) StartPush(ref string) *Progress {
+	p := NewProgress(ref)
+	m.statusMu.Lock()
+	if m.pushes == nil {
+		m.pushes = make(map[string]*Progress)
+	}
+	m.pushes[ref] = p
+	m.statusMu.Unlock()
+	return p
+}
+
+// Push uploads ref to its registry using the Docker CLI credentials.
+func (m *Manager) Push(ref string) error {
+	if m == nil || m.cli == nil {
+		return fmt.Errorf("image manager not initialized")
+	}
+
+	m.SetPullStatus(ref, fmt.Sprintf(" [%s]⟳ Pushing...[-]", styles.ColorStatusOrange.String()))
+
+	progress := m.GetPushProgress(ref)
+	if progress == nil {
+		progress = m.StartPush(ref)
+	} else if _, _, done, _ := progress.Snapshot(); done {
+		progress = m.StartPush(ref)
+	}
+
+	fail := func(err error) error {
+		progress.Finish(err)
+		m.SetPullStatus(ref, fmt.Sprintf(" [%s]✘ Push error[-]", styles.TagError))
+		go func() {
+			time.Sleep(5 * time.Second)
+			m.SetPullStatus(ref, "")
+		}()
+		return err
+	}
+
+	auth := EncodedAuth(ref)
+	if auth == "" {
+		// Older daemons reject pushes without an X-Registry-Auth header
+		var err error
+		if auth, err = registry.EncodeAuthConfig(registry.AuthConfig{}); err != nil {
+			return fail(err)
+		}
+	}
+
+	reader, err := m.cli.ImagePush(m.ctx, ref, image.PushOptions{RegistryAuth: auth})
+	if err != nil {
+		return fail(err)
+	}
+	defer reader.Close()
+
+	if err := progress.Consume(reader); err != nil {
+		return fail(err)
+	}
+
+	m.SetPullStatus(ref, fmt.Sprintf(" [%s]✔ Pushed[-]", styles.ColorSelect.String()))
+	go func() {
+		time.Sleep(5 * time.Second)
+		m.SetPullStatus(ref, "")
+	}()
+
+	return nil
+}
+
+// Save writes the images (IDs or references) to a local tar archive, like
+// docker save. The file is written on this machine, even for remote daemons.
+// An existing file is only replaced once the whole archive is written.
+func (m *Manager) Save(refs []string, path string) (int64, error) {
+	reader, err := m.cli.ImageSave(m.ctx, refs)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return 0, err
+	}
+	f.Chmod(0o644) // Temp files are private, archives are not
+
+	n, err := io.Copy(f, reader)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return 0, err
+	}
+	return n, nil
+}
+
+// Load imports a local tar archive (docker save / OCI layout) into the daemon
+// and returns the loaded references.
+func (m *Manager) Load(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	resp, err := m.cli.ImageLoad(m.ctx, f, client.ImageLoadWithQuiet(false))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	progress := NewProgress(path)
+	if err := progress.Consume(resp.Body); err != nil {
+		return nil, err
+	}
+
+	var loaded []string
+	_, messages, _, _ := progress.Snapshot()
+	for _, msg := range messages {
+		if ref, ok := strings.CutPrefix(msg, "Loaded image: "); ok {
+			loaded = append(loaded, strings.TrimSpace(ref))
+		} else if id, ok := strings.CutPrefix(msg, "Loaded image ID: "); ok {
+			loaded = append(loaded, strings.TrimSpace(id))
+		}
+	}
+	return loaded, nil
+}
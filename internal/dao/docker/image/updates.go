@@ -0,0 +1,194 @@
+package image
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/distribution/reference"
+)
+
+// Update states of the image a container runs
+const (
+	UpdateUnknown   = ""
+	UpdateCurrent   = "current"
+	UpdateAvailable = "available"
+)
+
+// Failed checks are retried sooner than the regular interval
+const updateErrorTTL = 5 * time.Minute
+
+// At most this many registry checks run at the same time
+var updateSem = make(chan struct{}, 3)
+
+type updateEntry struct {
+	at      time.Time
+	imageID string // Local image the status was computed for
+	status  string
+	err     error
+	pending bool
+}
+
+type updateChecks struct {
+	mu      sync.Mutex
+	entries map[string]*updateEntry
+}
+
+// UpdateStatus tells whether the registry serves a newer image for ref than
+// the local image imageID. It never blocks: unknown entries and entries older
+// than interval are checked in the background and UpdateUnknown is returned
+// meanwhile. References pinned by digest, image IDs and local builds are
+// never checked.
+func (m *Manager) UpdateStatus(ref, imageID string, interval time.Duration) string {
+	named, ok := updatableRef(ref, imageID)
+	if !ok {
+		return UpdateUnknown
+	}
+	key := named.String()
+
+	m.updates.mu.Lock()
+	defer m.updates.mu.Unlock()
+	if m.updates.entries == nil {
+		m.updates.entries = make(map[string]*updateEntry)
+	}
+
+	e, ok := m.updates.entries[key]
+	if ok && e.imageID == imageID {
+		ttl := interval
+		if e.err != nil {
+			ttl = min(interval, updateErrorTTL)
+		}
+		if e.pending || time.Since(e.at) < ttl {
+			return e.status
+		}
+	}
+	if !ok || e.imageID != imageID {
+		e = &updateEntry{imageID: imageID}
+		m.updates.entries[key] = e
+	}
+	e.pending = true
+	go m.checkUpdate(key, named, imageID)
+	return e.status
+}
+
+// ForgetUpdate drops the cached status of ref so the next call checks again.
+func (m *Manager) ForgetUpdate(ref string) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return
+	}
+	m.updates.mu.Lock()
+	delete(m.updates.entries, reference.TagNameOnly(named).String())
+	m.updates.mu.Unlock()
+}
+
+func (m *Manager) checkUpdate(key string, named reference.NamedTagged, imageID string) {
+	updateSem <- struct{}{}
+	defer func() { <-updateSem }()
+
+	status, err := m.compareDigests(named, imageID)
+
+	m.updates.mu.Lock()
+	defer m.updates.mu.Unlock()
+	e, ok := m.updates.entries[key]
+	if !ok || e.imageID != imageID {
+		return // Superseded by a newer local image
+	}
+	e.status, e.err, e.at, e.pending = status, err, time.Now(), false
+}
+
+func (m *Manager) compareDigests(named reference.NamedTagged, imageID string) (string, error) {
+	info, err := m.cli.ImageInspect(m.ctx, imageID)
+	if err != nil {
+		return UpdateUnknown, err
+	}
+
+	// RepoDigests record the digest each repository served when it was pulled
+	local := make(map[string]bool)
+	for _, rd := range info.RepoDigests {
+		canonical, err := reference.ParseNormalizedNamed(rd)
+		if err != nil {
+			continue
+		}
+		if d, ok := canonical.(reference.Canonical); ok && canonical.Name() == named.Name() {
+			local[d.Digest().String()] = true
+		}
+	}
+	if len(local) == 0 {
+		return UpdateUnknown, nil // Built locally, never pulled from the registry
+	}
+
+	remote, err := m.RemoteDigest(named.String())
+	if err != nil {
+		return UpdateUnknown, err
+	}
+	if local[remote] {
+		return UpdateCurrent, nil
+	}
+	return UpdateAvailable, nil
+}
+
+// updatableRef returns the tagged reference to check, or false when ref is
+// pinned by digest or is the image ID itself (e.g. an image re-tagged since).
+func updatableRef(ref, imageID string) (reference.NamedTagged, bool) {
+	id := strings.TrimPrefix(ref, "sha256:")
+	if ref == "" || (len(id) >= 12 && strings.HasPrefix(strings.TrimPrefix(imageID, "sha256:"), id)) {
+		return nil, false
+	}
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return nil, false
+	}
+	if _, pinned := named.(reference.Canonical); pinned {
+		return nil, false
+	}
+	tagged, ok := reference.TagNameOnly(named).(reference.NamedTagged)
+	return tagged, ok
+}
+
+// RemoteDigest returns the manifest digest the registry currently serves for
+// a tagged reference. It uses a HEAD request, which Docker Hub does not count
+// against its pull rate limit.
+func (m *Manager) RemoteDigest(ref string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", err
+	}
+	tagged, ok := reference.TagNameOnly(named).(reference.NamedTagged)
+	if !ok {
+		return "", fmt.Errorf("no tag in reference %q", ref)
+	}
+	rc := m.registryClientFor(named)
+	path := fmt.Sprintf("/v2/%s/manifests/%s", rc.repo, tagged.Tag())
+
+	resp, err := rc.request(http.MethodHead, path, manifestAccept)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("%s: %s", rc.host, resp.Status)
+	}
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+
+	// Some registries omit the digest header, hash the manifest instead
+	resp, err = rc.get(path, manifestAccept)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("%s: %s", rc.host, resp.Status)
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, resp.Body); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", h.Sum(nil)), nil
+}
@@ -2,6 +2,7 @@ package network
 
 import (
 	"fmt"
+	"net/netip"
 	"strings"
 
 	"github.com/docker/docker/api/types/container"
@@ -135,8 +136,129 @@ func (m *Manager) List() ([]common.Resource, error) {
 	return res, nil
 }
 
-func (m *Manager) Create(name string) error {
-	_, err := m.cli.NetworkCreate(m.ctx, name, network.CreateOptions{})
+// CreateSpec describes a network to create, as typed in the create form
+type CreateSpec struct {
+	Name       string
+	Driver     string // bridge (default), overlay, macvlan, ipvlan...
+	Subnet     string // CIDR
+	Gateway    string
+	IPRange    string // CIDR inside the subnet, for dynamic allocation
+	Internal   bool
+	Attachable bool // Overlay only: standalone containers can join
+	IPv6       bool
+	Labels     []string // key=value
+	Options    []string // Driver options, key=value (e.g. parent=eth0)
+}
+
+// CreateOptions validates the spec and converts it into the API payload.
+func (s CreateSpec) CreateOptions() (network.CreateOptions, error) {
+	opts := network.CreateOptions{
+		Driver:     strings.TrimSpace(s.Driver),
+		Internal:   s.Internal,
+		Attachable: s.Attachable,
+	}
+	if strings.TrimSpace(s.Name) == "" {
+		return opts, fmt.Errorf("network name is required")
+	}
+	if opts.Driver == "" {
+		opts.Driver = "bridge"
+	}
+	if s.Attachable && opts.Driver != "overlay" {
+		return opts, fmt.Errorf("attachable is only supported by overlay networks")
+	}
+	if s.IPv6 {
+		ipv6 := true
+		opts.EnableIPv6 = &ipv6
+	}
+
+	subnet, gateway, ipRange := strings.TrimSpace(s.Subnet), strings.TrimSpace(s.Gateway), strings.TrimSpace(s.IPRange)
+	if subnet != "" {
+		prefix, err := netip.ParsePrefix(subnet)
+		if err != nil {
+			return opts, fmt.Errorf("invalid subnet %q: %v", subnet, err)
+		}
+		if prefix.Addr().Is6() && !s.IPv6 {
+			return opts, fmt.Errorf("subnet %s is IPv6, enable IPv6", subnet)
+		}
+		conf := network.IPAMConfig{Subnet: prefix.Masked().String()}
+		if gateway != "" {
+			gw, err := netip.ParseAddr(gateway)
+			if err != nil || !prefix.Contains(gw) {
+				return opts, fmt.Errorf("gateway %q is not an address of %s", gateway, subnet)
+			}
+			conf.Gateway = gw.String()
+		}
+		if ipRange != "" {
+			r, err := netip.ParsePrefix(ipRange)
+			if err != nil || r.Bits() < prefix.Bits() || !prefix.Contains(r.Addr()) {
+				return opts, fmt.Errorf("IP range %q is not inside %s", ipRange, subnet)
+			}
+			conf.IPRange = r.Masked().String()
+		}
+		opts.IPAM = &network.IPAM{Driver: "default", Config: []network.IPAMConfig{conf}}
+	} else if gateway != "" || ipRange != "" {
+		return opts, fmt.Errorf("gateway and IP range need a subnet")
+	}
+
+	labels, err := parseKeyValues(s.Labels, "label")
+	if err != nil {
+		return opts, err
+	}
+	options, err := parseKeyValues(s.Options, "driver option")
+	if err != nil {
+		return opts, err
+	}
+	opts.Labels, opts.Options = labels, options
+	return opts, nil
+}
+
+func parseKeyValues(items []string, kind string) (map[string]string, error) {
+	out := make(map[string]string, len(items))
+	for _, item := range items {
+		k, v, _ := strings.Cut(item, "=")
+		if k = strings.TrimSpace(k); k == "" {
+			return nil, fmt.Errorf("invalid %s: %q", kind, item)
+		}
+		out[k] = strings.TrimSpace(v)
+	}
+	return out, nil
+}
+
+// DockerCommand returns the equivalent docker network create command line.
+func (s CreateSpec) DockerCommand() string {
+	args := []string{"docker", "network", "create"}
+	if d := strings.TrimSpace(s.Driver); d != "" && d != "bridge" {
+		args = append(args, "--driver", d)
+	}
+	for _, f := range [][2]string{{"--subnet", s.Subnet}, {"--gateway", s.Gateway}, {"--ip-range", s.IPRange}} {
+		if value := strings.TrimSpace(f[1]); value != "" {
+			args = append(args, f[0], value)
+		}
+	}
+	if s.Internal {
+		args = append(args, "--internal")
+	}
+	if s.Attachable {
+		args = append(args, "--attachable")
+	}
+	if s.IPv6 {
+		args = append(args, "--ipv6")
+	}
+	for _, o := range s.Options {
+		args = append(args, "--opt", common.ShellQuote(strings.TrimSpace(o)))
+	}
+	for _, l := range s.Labels {
+		args = append(args, "--label", common.ShellQuote(l))
+	}
+	return strings.Join(append(args, common.ShellQuote(strings.TrimSpace(s.Name))), " ")
+}
+
+func (m *Manager) Create(spec CreateSpec) error {
+	opts, err := spec.CreateOptions()
+	if err != nil {
+		return err
+	}
+	_, err = m.cli.NetworkCreate(m.ctx, strings.TrimSpace(spec.Name), opts)
 	return err
 }
 
@@ -149,8 +271,65 @@ func (m *Manager) Prune() error {
 	return err
 }
 
-func (m *Manager) Connect(networkID, containerID string) error {
-	return m.cli.NetworkConnect(m.ctx, networkID, containerID, nil)
+// ConnectSpec holds the endpoint settings of a container joining a network
+type ConnectSpec struct {
+	Aliases []string
+	IPv4    string   // Static address, the network needs a user-defined subnet
+	IPv6    string
+	Links   []string // container[:alias]
+}
+
+// EndpointSettings validates the spec and converts it into the API payload,
+// nil when nothing is set.
+func (s ConnectSpec) EndpointSettings() (*network.EndpointSettings, error) {
+	settings := &network.EndpointSettings{}
+	for _, a := range s.Aliases {
+		if a = strings.TrimSpace(a); a != "" {
+			settings.Aliases = append(settings.Aliases, a)
+		}
+	}
+	for _, l := range s.Links {
+		name, alias, _ := strings.Cut(strings.TrimSpace(l), ":")
+		if name == "" {
+			continue
+		}
+		if alias == "" {
+			alias = name
+		}
+		settings.Links = append(settings.Links, name+":"+alias)
+	}
+
+	ipv4, ipv6 := strings.TrimSpace(s.IPv4), strings.TrimSpace(s.IPv6)
+	if ipv4 != "" || ipv6 != "" {
+		settings.IPAMConfig = &network.EndpointIPAMConfig{}
+	}
+	if ipv4 != "" {
+		addr, err := netip.ParseAddr(ipv4)
+		if err != nil || !addr.Is4() {
+			return nil, fmt.Errorf("invalid IPv4 address %q", ipv4)
+		}
+		settings.IPAMConfig.IPv4Address = addr.String()
+	}
+	if ipv6 != "" {
+		addr, err := netip.ParseAddr(ipv6)
+		if err != nil || !addr.Is6() || addr.Is4In6() {
+			return nil, fmt.Errorf("invalid IPv6 address %q", ipv6)
+		}
+		settings.IPAMConfig.IPv6Address = addr.String()
+	}
+
+	if len(settings.Aliases) == 0 && len(settings.Links) == 0 && settings.IPAMConfig == nil {
+		return nil, nil
+	}
+	return settings, nil
+}
+
+func (m *Manager) Connect(networkID, containerID string, spec ConnectSpec) error {
+	settings, err := spec.EndpointSettings()
+	if err != nil {
+		return err
+	}
+	return m.cli.NetworkConnect(m.ctx, networkID, containerID, settings)
 }
 
 func (m *Manager) Disconnect(networkID, containerID string) error {
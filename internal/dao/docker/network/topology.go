@@ -0,0 +1,178 @@
+package network
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+)
+
+// Endpoint is a container attached to a network
+type Endpoint struct {
+	ContainerID string
+	Name        string
+	State       string
+	Project     string // Compose project, empty for standalone containers
+	IPv4        string
+	IPv6        string
+	Aliases     []string
+	Ports       []string // Published ports, host:public->private/proto
+	Networks    int      // Number of networks the container is attached to
+}
+
+// TopologyNetwork is a network with its attached containers
+type TopologyNetwork struct {
+	ID        string
+	Name      string
+	Driver    string
+	Subnet    string
+	Internal  bool
+	Endpoints []Endpoint
+}
+
+// Containers inspected at once to read their network aliases
+const topologyConcurrency = 8
+
+// Topology returns every network with the containers attached to it,
+// networks with containers first.
+func (m *Manager) Topology() ([]TopologyNetwork, error) {
+	list, err := m.cli.NetworkList(m.ctx, network.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	containers, err := m.cli.ContainerList(m.ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := m.containerAliases(containers)
+
+	endpoints := make(map[string][]Endpoint)
+	for _, c := range containers {
+		if c.NetworkSettings == nil {
+			continue
+		}
+		name := c.ID[:12]
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		ports := publishedPorts(c.Ports)
+
+		for _, settings := range c.NetworkSettings.Networks {
+			if settings == nil || settings.NetworkID == "" {
+				continue
+			}
+			ep := Endpoint{
+				ContainerID: c.ID,
+				Name:        name,
+				State:       c.State,
+				Project:     c.Labels["com.docker.compose.project"],
+				IPv4:        settings.IPAddress,
+				IPv6:        settings.GlobalIPv6Address,
+				Ports:       ports,
+				Networks:    len(c.NetworkSettings.Networks),
+			}
+			// The daemon adds the container name and short ID, keep the user defined ones
+			for _, alias := range aliases[c.ID][settings.NetworkID] {
+				if alias != name && !strings.HasPrefix(c.ID, alias) && !contains(ep.Aliases, alias) {
+					ep.Aliases = append(ep.Aliases, alias)
+				}
+			}
+			endpoints[settings.NetworkID] = append(endpoints[settings.NetworkID], ep)
+		}
+	}
+
+	res := make([]TopologyNetwork, 0, len(list))
+	for _, n := range list {
+		var subnets []string
+		for _, conf := range n.IPAM.Config {
+			if conf.Subnet != "" {
+				subnets = append(subnets, conf.Subnet)
+			}
+		}
+		eps := endpoints[n.ID]
+		sort.Slice(eps, func(i, j int) bool { return eps[i].Name < eps[j].Name })
+		res = append(res, TopologyNetwork{
+			ID:        n.ID,
+			Name:      n.Name,
+			Driver:    n.Driver,
+			Subnet:    strings.Join(subnets, ", "),
+			Internal:  n.Internal,
+			Endpoints: eps,
+		})
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if (len(res[i].Endpoints) > 0) != (len(res[j].Endpoints) > 0) {
+			return len(res[i].Endpoints) > 0
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res, nil
+}
+
+// containerAliases returns the aliases of each container per network ID. The
+// container list does not carry them, they are read from ContainerInspect.
+func (m *Manager) containerAliases(containers []container.Summary) map[string]map[string][]string {
+	res := make(map[string]map[string][]string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, topologyConcurrency)
+	for _, c := range containers {
+		if c.NetworkSettings == nil || len(c.NetworkSettings.Networks) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			info, err := m.cli.ContainerInspect(m.ctx, id)
+			if err != nil || info.NetworkSettings == nil {
+				return // Listed without aliases
+			}
+			perNetwork := make(map[string][]string)
+			for _, settings := range info.NetworkSettings.Networks {
+				if settings != nil && settings.NetworkID != "" {
+					perNetwork[settings.NetworkID] = settings.Aliases
+				}
+			}
+			mu.Lock()
+			res[id] = perNetwork
+			mu.Unlock()
+		}(c.ID)
+	}
+	wg.Wait()
+	return res
+}
+
+func publishedPorts(ports []container.Port) []string {
+	var out []string
+	for _, p := range ports {
+		if p.PublicPort == 0 {
+			continue
+		}
+		host := p.IP
+		if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+		entry := fmt.Sprintf("%s:%d->%d/%s", host, p.PublicPort, p.PrivatePort, p.Type)
+		if !contains(out, entry) {
+			out = append(out, entry)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
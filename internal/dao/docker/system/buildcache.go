@@ -0,0 +1,185 @@
+package system
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/versions"
+	"github.com/docker/go-units"
+	"github.com/gdamore/tcell/v2"
+	"github.com/jr-k/d4s/internal/dao/common"
+	"github.com/jr-k/d4s/internal/ui/styles"
+)
+
+// BuildCache is one BuildKit cache record
+type BuildCache struct {
+	ID          string
+	Parents     []string
+	Type        string
+	Description string
+	Size        int64
+	CreatedAt   time.Time
+	LastUsedAt  *time.Time
+	UsageCount  int
+	Shared      bool
+	InUse       bool
+}
+
+func (b BuildCache) GetID() string { return b.ID }
+func (b BuildCache) GetCells() []string {
+	return []string{b.shortID(), b.Type, common.FormatBytes(b.Size), b.lastUsed(), yesNo(b.Shared), yesNo(b.InUse), b.Description}
+}
+
+func (b BuildCache) shortID() string {
+	return b.ID[:min(12, len(b.ID))]
+}
+
+func (b BuildCache) lastUsed() string {
+	if b.LastUsedAt == nil {
+		return "never"
+	}
+	return common.FormatTime(b.LastUsedAt.Unix())
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "-"
+}
+
+func (b BuildCache) GetStatusColor() (tcell.Color, tcell.Color) {
+	if b.InUse {
+		return styles.ColorStatusGreen, styles.ColorBlack
+	}
+	if b.Shared {
+		return styles.ColorStatusGray, styles.ColorBlack
+	}
+	return styles.ColorIdle, styles.ColorBlack
+}
+
+func (b BuildCache) GetColumnValue(column string) string {
+	switch strings.ToLower(column) {
+	case "id":
+		return b.ID
+	case "type":
+		return b.Type
+	case "size":
+		return common.FormatBytes(b.Size)
+	case "last used":
+		return b.lastUsed()
+	case "shared":
+		return yesNo(b.Shared)
+	case "in use":
+		return yesNo(b.InUse)
+	case "description":
+		return b.Description
+	}
+	return ""
+}
+
+func (b BuildCache) GetDefaultColumn() string {
+	return "Description"
+}
+
+func (b BuildCache) GetDefaultSortColumn() string {
+	return "Size"
+}
+
+// ListBuildCache returns the build cache records (DiskUsage restricted to the build cache).
+func (m *Manager) ListBuildCache() ([]common.Resource, error) {
+	du, err := m.cli.DiskUsage(m.ctx, types.DiskUsageOptions{Types: []types.DiskUsageObject{types.BuildCacheObject}})
+	if err != nil {
+		return nil, err
+	}
+
+	var res []common.Resource
+	for _, r := range du.BuildCache {
+		if r == nil {
+			continue
+		}
+		res = append(res, BuildCache{
+			ID:          r.ID,
+			Parents:     r.Parents,
+			Type:        r.Type,
+			Description: r.Description,
+			Size:        r.Size,
+			CreatedAt:   r.CreatedAt,
+			LastUsedAt:  r.LastUsedAt,
+			UsageCount:  r.UsageCount,
+			Shared:      r.Shared,
+			InUse:       r.InUse,
+		})
+	}
+	return res, nil
+}
+
+// BuildCachePruneOptions are the user filters of a build cache prune
+type BuildCachePruneOptions struct {
+	OlderThan   string // Unused for at least this long (e.g. 24h, 7d)
+	KeepStorage string // Keep at most this much cache (e.g. 10g)
+	All         bool   // Include shared and internal records
+}
+
+// PruneBuildCache removes the build cache matching opts and returns the reclaimed size.
+func (m *Manager) PruneBuildCache(opts BuildCachePruneOptions) (int64, error) {
+	pruneOpts := build.CachePruneOptions{All: opts.All, Filters: filters.NewArgs()}
+
+	if age := strings.TrimSpace(opts.OlderThan); age != "" {
+		d, err := ParseAge(age)
+		if err != nil {
+			return 0, err
+		}
+		pruneOpts.Filters.Add("until", d.String())
+	}
+
+	if keep := strings.TrimSpace(opts.KeepStorage); keep != "" {
+		size, err := units.RAMInBytes(keep)
+		if err != nil || size < 0 {
+			return 0, fmt.Errorf("invalid storage size: %q", keep)
+		}
+		// keep-storage was replaced by max-used-space in API 1.48
+		if versions.LessThan(m.cli.ClientVersion(), "1.48") {
+			pruneOpts.KeepStorage = size
+		} else {
+			pruneOpts.MaxUsedSpace = size
+		}
+	}
+
+	report, err := m.cli.BuildCachePrune(m.ctx, pruneOpts)
+	if err != nil {
+		return 0, err
+	}
+	return int64(report.SpaceReclaimed), nil
+}
+
+// RemoveBuildCache removes a single cache record.
+func (m *Manager) RemoveBuildCache(id string) error {
+	_, err := m.cli.BuildCachePrune(m.ctx, build.CachePruneOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("id", id)),
+	})
+	return err
+}
+
+// ParseAge parses a Go duration, also accepting whole days (e.g. "7d").
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age: %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age: %q", s)
+	}
+	return d, nil
+}
@@ -0,0 +1,340 @@
+package system
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-units"
+	"github.com/gdamore/tcell/v2"
+	"github.com/jr-k/d4s/internal/dao/common"
+	"github.com/jr-k/d4s/internal/dao/docker/volume"
+	"github.com/jr-k/d4s/internal/ui/styles"
+	"golang.org/x/net/context"
+)
+
+// Candidate kinds, in removal order
+const (
+	KindContainer  = "container"
+	KindTag        = "tag"
+	KindImage      = "image"
+	KindVolume     = "volume"
+	KindBuildCache = "build cache"
+)
+
+var kindOrder = map[string]int{KindContainer: 0, KindTag: 1, KindImage: 2, KindVolume: 3, KindBuildCache: 4}
+
+type Manager struct {
+	cli *client.Client
+	ctx context.Context
+}
+
+func NewManager(cli *client.Client, ctx context.Context) *Manager {
+	return &Manager{cli: cli, ctx: ctx}
+}
+
+// Category is the disk usage of one resource type (docker system df)
+type Category struct {
+	Name        string
+	Total       int
+	Active      int
+	Size        int64
+	Reclaimable int64
+}
+
+// DuplicateTag lists the references pointing to the same image ID
+type DuplicateTag struct {
+	ID   string
+	Tags []string
+	Size int64
+}
+
+// Candidate is something that can be removed to reclaim space
+type Candidate struct {
+	Kind    string
+	Ref     string // ID, tag or name passed to the remove call
+	Name    string
+	Size    int64 // Space reclaimed by removing it
+	Reason  string
+	Created time.Time
+	Shared  bool // Build cache shared with other records, not reclaimed on its own
+}
+
+func (c Candidate) GetID() string { return c.Kind + ":" + c.Ref }
+func (c Candidate) GetCells() []string {
+	created := ""
+	if !c.Created.IsZero() {
+		created = common.FormatTime(c.Created.Unix())
+	}
+	return []string{c.Kind, c.Name, common.FormatBytes(c.Size), c.Reason, created}
+}
+
+func (c Candidate) GetStatusColor() (tcell.Color, tcell.Color) {
+	switch c.Kind {
+	case KindTag:
+		return styles.ColorStatusGray, styles.ColorBlack
+	case KindContainer:
+		return styles.ColorStatusYellow, styles.ColorBlack
+	}
+	return styles.ColorIdle, styles.ColorBlack
+}
+
+func (c Candidate) GetColumnValue(column string) string {
+	switch strings.ToLower(column) {
+	case "kind":
+		return c.Kind
+	case "name":
+		return c.Name
+	case "size":
+		return common.FormatBytes(c.Size)
+	case "reason":
+		return c.Reason
+	case "created":
+		if c.Created.IsZero() {
+			return ""
+		}
+		return common.FormatTime(c.Created.Unix())
+	}
+	return ""
+}
+
+func (c Candidate) GetDefaultColumn() string {
+	return "Name"
+}
+
+func (c Candidate) GetDefaultSortColumn() string {
+	return "Size"
+}
+
+// Analysis is the result of a disk usage scan
+type Analysis struct {
+	LayersSize int64
+	UnusedDays int
+	Categories []Category
+	Duplicates []DuplicateTag
+	Unused     []Candidate // Tagged images unused for UnusedDays or more
+	Candidates []Candidate
+}
+
+// Reclaimable returns the total reclaimable size, summed over the categories.
+func (a *Analysis) Reclaimable() int64 {
+	var total int64
+	for _, c := range a.Categories {
+		total += c.Reclaimable
+	}
+	return total
+}
+
+// Analyze scans disk usage and lists what can be reclaimed. Images are only
+// proposed when no container uses them and they are older than unusedDays.
+func (m *Manager) Analyze(unusedDays int) (*Analysis, error) {
+	du, err := m.cli.DiskUsage(m.ctx, types.DiskUsageOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Analysis{LayersSize: du.LayersSize, UnusedDays: unusedDays}
+	cutoff := time.Now().AddDate(0, 0, -unusedDays)
+
+	// Images
+	images := Category{Name: "Images"}
+	for _, img := range du.Images {
+		if img == nil {
+			continue
+		}
+		id := strings.TrimPrefix(img.ID, "sha256:")
+		created := time.Unix(img.Created, 0)
+		unique := img.Size
+		if img.SharedSize > 0 {
+			unique -= img.SharedSize
+		}
+
+		images.Total++
+		images.Size += img.Size
+		if img.Containers > 0 {
+			images.Active++
+		} else {
+			images.Reclaimable += unique
+		}
+
+		tags := realTags(img.RepoTags)
+		if len(tags) > 1 {
+			a.Duplicates = append(a.Duplicates, DuplicateTag{ID: id, Tags: tags, Size: img.Size})
+			for _, tag := range tags[1:] {
+				a.Candidates = append(a.Candidates, Candidate{
+					Kind: KindTag, Ref: tag, Name: tag, Created: created,
+					Reason: fmt.Sprintf("same image as %s", tags[0]),
+				})
+			}
+		}
+
+		if img.Containers > 0 {
+			continue
+		}
+		switch {
+		case len(tags) == 0:
+			a.Candidates = append(a.Candidates, Candidate{
+				Kind: KindImage, Ref: id, Name: id[:min(12, len(id))], Size: unique, Created: created,
+				Reason: "dangling",
+			})
+		case created.Before(cutoff):
+			c := Candidate{
+				Kind: KindImage, Ref: id, Name: strings.Join(tags, ", "), Size: unique, Created: created,
+				Reason: fmt.Sprintf("unused, created %s ago", units.HumanDuration(time.Since(created))),
+			}
+			a.Unused = append(a.Unused, c)
+			a.Candidates = append(a.Candidates, c)
+		}
+	}
+
+	// Containers
+	containers := Category{Name: "Containers"}
+	for _, c := range du.Containers {
+		if c == nil {
+			continue
+		}
+		containers.Total++
+		containers.Size += c.SizeRw
+		if c.State == container.StateRunning || c.State == container.StatePaused || c.State == container.StateRestarting {
+			containers.Active++
+			continue
+		}
+		containers.Reclaimable += c.SizeRw
+
+		name := c.ID[:min(12, len(c.ID))]
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		a.Candidates = append(a.Candidates, Candidate{
+			Kind: KindContainer, Ref: c.ID, Name: name, Size: c.SizeRw, Created: time.Unix(c.Created, 0),
+			Reason: strings.ToLower(c.Status),
+		})
+	}
+
+	// Volumes
+	volumes := Category{Name: "Local Volumes"}
+	for _, v := range du.Volumes {
+		if v == nil {
+			continue
+		}
+		var size, refs int64
+		if v.UsageData != nil {
+			size, refs = max(v.UsageData.Size, 0), v.UsageData.RefCount
+		}
+		volumes.Total++
+		volumes.Size += size
+		if refs != 0 {
+			volumes.Active++
+			continue
+		}
+		volumes.Reclaimable += size
+
+		reason := "unreferenced"
+		if volume.IsAnonymousVolume(v.Name) {
+			reason = "unreferenced, anonymous"
+		}
+		created, _ := time.Parse(time.RFC3339, v.CreatedAt)
+		a.Candidates = append(a.Candidates, Candidate{
+			Kind: KindVolume, Ref: v.Name, Name: v.Name, Size: size, Created: created,
+			Reason: reason,
+		})
+	}
+
+	// Build cache
+	cache := Category{Name: "Build Cache"}
+	for _, r := range du.BuildCache {
+		if r == nil {
+			continue
+		}
+		cache.Total++
+		cache.Size += r.Size
+		if r.InUse {
+			cache.Active++
+			continue
+		}
+		if !r.Shared {
+			cache.Reclaimable += r.Size
+		}
+
+		reason := "never used"
+		if r.LastUsedAt != nil {
+			reason = fmt.Sprintf("last used %s ago", units.HumanDuration(time.Since(*r.LastUsedAt)))
+		}
+		if r.Shared {
+			reason += ", shared"
+		}
+		name := r.Type
+		if r.Description != "" {
+			name = r.Type + ": " + r.Description
+		}
+		a.Candidates = append(a.Candidates, Candidate{
+			Kind: KindBuildCache, Ref: r.ID, Name: name, Size: r.Size, Created: r.CreatedAt,
+			Reason: reason, Shared: r.Shared,
+		})
+	}
+
+	a.Categories = []Category{images, containers, volumes, cache}
+	sort.Slice(a.Duplicates, func(i, j int) bool { return a.Duplicates[i].Tags[0] < a.Duplicates[j].Tags[0] })
+	return a, nil
+}
+
+func realTags(tags []string) []string {
+	var out []string
+	for _, t := range tags {
+		if t != "" && t != "<none>:<none>" {
+			out = append(out, t)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Remove deletes the given candidates (containers first, then tags, images,
+// volumes and build cache) and returns the reclaimed size and the failures.
+func (m *Manager) Remove(candidates []Candidate) (int64, []string) {
+	sorted := append([]Candidate(nil), candidates...)
+	sort.SliceStable(sorted, func(i, j int) bool { return kindOrder[sorted[i].Kind] < kindOrder[sorted[j].Kind] })
+
+	var reclaimed int64
+	var errs []string
+	for _, c := range sorted {
+		var err error
+		switch c.Kind {
+		case KindContainer:
+			err = m.cli.ContainerRemove(m.ctx, c.Ref, container.RemoveOptions{})
+		case KindTag:
+			// Removing a reference only untags while other tags remain
+			_, err = m.cli.ImageRemove(m.ctx, c.Ref, image.RemoveOptions{})
+		case KindImage:
+			// Unused images may still carry several tags, force drops them all
+			_, err = m.cli.ImageRemove(m.ctx, c.Ref, image.RemoveOptions{Force: true, PruneChildren: true})
+		case KindVolume:
+			err = m.cli.VolumeRemove(m.ctx, c.Ref, false)
+		case KindBuildCache:
+			var report *build.CachePruneReport
+			report, err = m.cli.BuildCachePrune(m.ctx, build.CachePruneOptions{
+				All:     true,
+				Filters: filters.NewArgs(filters.Arg("id", c.Ref)),
+			})
+			if err == nil && report != nil {
+				reclaimed += int64(report.SpaceReclaimed)
+				continue
+			}
+		default:
+			err = fmt.Errorf("unknown kind %q", c.Kind)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s %s: %v", c.Kind, c.Name, err))
+			continue
+		}
+		reclaimed += c.Size
+	}
+	return reclaimed, errs
+}
@@ -0,0 +1,172 @@
+package volume
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	volTypes "github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// HelperMountPoint is where helper containers mount the volume
+const HelperMountPoint = "/data"
+
+func helperMount(name, target string, readOnly bool) mount.Mount {
+	return mount.Mount{Type: mount.TypeVolume, Source: name, Target: target, ReadOnly: readOnly}
+}
+
+func (m *Manager) createHelper(image string, cmd []string, mounts ...mount.Mount) (string, error) {
+	cfg := &container.Config{
+		Image:  image,
+		Cmd:    cmd,
+		Labels: map[string]string{"d4s.helper": "volume"},
+	}
+	hostCfg := &container.HostConfig{Mounts: mounts}
+	helperName := fmt.Sprintf("d4s-vol-helper-%d", time.Now().UnixNano())
+	resp, err := m.cli.ContainerCreate(m.ctx, cfg, hostCfg, nil, nil, helperName)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// CreateHelper creates (without starting) a container of image with the
+// volume mounted at HelperMountPoint, so its content is reachable through
+// the container archive API, locally or over SSH.
+func (m *Manager) CreateHelper(name, image string, readOnly bool) (string, error) {
+	return m.createHelper(image, []string{"true"}, helperMount(name, HelperMountPoint, readOnly))
+}
+
+// StartHelper starts an idle container of image with the volume mounted
+// read-only at HelperMountPoint, so directories can be listed inside it
+// without downloading their content.
+func (m *Manager) StartHelper(name, image string) (string, error) {
+	id, err := m.createHelper(image, []string{"sleep", "86400"}, helperMount(name, HelperMountPoint, true))
+	if err != nil {
+		return "", err
+	}
+	if err := m.cli.ContainerStart(m.ctx, id, container.StartOptions{}); err != nil {
+		m.RemoveHelper(id)
+		return "", err
+	}
+	return id, nil
+}
+
+// RemoveHelper deletes a helper container created by CreateHelper or StartHelper.
+func (m *Manager) RemoveHelper(id string) error {
+	return m.cli.ContainerRemove(m.ctx, id, container.RemoveOptions{Force: true})
+}
+
+// runHelper runs cmd in a helper container on the daemon side and waits for it
+func (m *Manager) runHelper(image string, cmd []string, mounts ...mount.Mount) error {
+	id, err := m.createHelper(image, cmd, mounts...)
+	if err != nil {
+		return err
+	}
+	defer m.RemoveHelper(id)
+
+	if err := m.cli.ContainerStart(m.ctx, id, container.StartOptions{}); err != nil {
+		return err
+	}
+	statusCh, errCh := m.cli.ContainerWait(m.ctx, id, container.WaitConditionNotRunning)
+	select {
+	case err := <-errCh:
+		return err
+	case status := <-statusCh:
+		if status.StatusCode == 0 {
+			return nil
+		}
+		msg := ""
+		if logs, err := m.cli.ContainerLogs(m.ctx, id, container.LogsOptions{ShowStderr: true, Tail: "5"}); err == nil {
+			var stderr bytes.Buffer
+			stdcopy.StdCopy(io.Discard, &stderr, logs)
+			logs.Close()
+			msg = strings.TrimSpace(stderr.String())
+		}
+		return fmt.Errorf("%s exited with code %d %s", strings.Join(cmd, " "), status.StatusCode, msg)
+	}
+}
+
+// TestMount mounts the volume in a short-lived helper container, the only way
+// to check the options of remote filesystems (NFS, CIFS...) which are only
+// used on the first mount.
+func (m *Manager) TestMount(name, image string) error {
+	return m.runHelper(image, []string{"true"}, helperMount(name, HelperMountPoint, true))
+}
+
+// Exists reports whether a volume with this name exists.
+func (m *Manager) Exists(name string) bool {
+	_, err := m.cli.VolumeInspect(m.ctx, name)
+	return err == nil
+}
+
+// RunningContainers returns the names of the running containers using the volume.
+func (m *Manager) RunningContainers(name string) ([]string, error) {
+	list, err := m.cli.ContainerList(m.ctx, container.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("volume", name), filters.Arg("status", "running")),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, c := range list {
+		if len(c.Names) > 0 {
+			names = append(names, strings.TrimPrefix(c.Names[0], "/"))
+		}
+	}
+	return names, nil
+}
+
+// Empty deletes the whole content of a volume.
+func (m *Manager) Empty(name, image string) error {
+	script := fmt.Sprintf("rm -rf %[1]s/* %[1]s/.[!.]* %[1]s/..?*", HelperMountPoint)
+	return m.runHelper(image, []string{"sh", "-c", script}, helperMount(name, HelperMountPoint, false))
+}
+
+// Clone creates dst with the driver, options and labels of src (except the
+// compose ones) and copies the content of src into it, on the daemon side.
+// A source backed by a device (bind, NFS, CIFS...) is cloned into a plain local
+// volume instead: the same options would mount the same storage. It reports
+// whether that happened.
+func (m *Manager) Clone(src, dst, image string) (bool, error) {
+	info, err := m.cli.VolumeInspect(m.ctx, src)
+	if err != nil {
+		return false, err
+	}
+	if m.Exists(dst) {
+		return false, fmt.Errorf("volume %s already exists", dst)
+	}
+
+	labels := make(map[string]string)
+	for k, v := range info.Labels {
+		if !strings.HasPrefix(k, "com.docker.compose.") {
+			labels[k] = v
+		}
+	}
+	opts := volTypes.CreateOptions{
+		Name:       dst,
+		Driver:     info.Driver,
+		DriverOpts: info.Options,
+		Labels:     labels,
+	}
+	plain := info.Options["device"] != ""
+	if plain {
+		opts.Driver, opts.DriverOpts = "local", nil
+	}
+	if _, err := m.cli.VolumeCreate(m.ctx, opts); err != nil {
+		return plain, err
+	}
+
+	err = m.runHelper(image, []string{"cp", "-a", "/from/.", "/to/"},
+		helperMount(src, "/from", true), helperMount(dst, "/to", false))
+	if err != nil {
+		m.cli.VolumeRemove(m.ctx, dst, true)
+	}
+	return plain, err
+}
@@ -0,0 +1,82 @@
+package volume
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+// DiskUsage walks every volume on the daemon, so results are reused for a while
+const usageTTL = 2 * time.Minute
+
+// Usage is the disk usage of a volume as reported by the daemon
+// (-1 when the driver doesn't report it)
+type Usage struct {
+	Size     int64
+	RefCount int64
+}
+
+type usageCache struct {
+	mu       sync.RWMutex
+	entries  map[string]Usage
+	updated  time.Time
+	fetching int32
+}
+
+// Usage returns the cached usage of a volume and refreshes the cache in the
+// background when stale. ok is false until the first computation is done.
+func (m *Manager) Usage(name string) (Usage, bool) {
+	m.usage.mu.RLock()
+	u, ok := m.usage.entries[name]
+	stale := time.Since(m.usage.updated) > usageTTL
+	m.usage.mu.RUnlock()
+
+	if stale {
+		m.refreshUsage()
+	}
+	return u, ok
+}
+
+// InvalidateUsage forces the next Usage call to recompute the sizes.
+func (m *Manager) InvalidateUsage() {
+	m.usage.mu.Lock()
+	m.usage.updated = time.Time{}
+	m.usage.mu.Unlock()
+}
+
+func (m *Manager) refreshUsage() {
+	if !atomic.CompareAndSwapInt32(&m.usage.fetching, 0, 1) {
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&m.usage.fetching, 0)
+
+		du, err := m.cli.DiskUsage(m.ctx, types.DiskUsageOptions{Types: []types.DiskUsageObject{types.VolumeObject}})
+		if err != nil {
+			// Retry on the next stale check rather than at every refresh
+			m.usage.mu.Lock()
+			m.usage.updated = time.Now().Add(-usageTTL / 2)
+			m.usage.mu.Unlock()
+			return
+		}
+
+		entries := make(map[string]Usage, len(du.Volumes))
+		for _, v := range du.Volumes {
+			if v == nil {
+				continue
+			}
+			u := Usage{Size: -1, RefCount: -1}
+			if v.UsageData != nil {
+				u = Usage{Size: v.UsageData.Size, RefCount: v.UsageData.RefCount}
+			}
+			entries[v.Name] = u
+		}
+
+		m.usage.mu.Lock()
+		m.usage.entries = entries
+		m.usage.updated = time.Now()
+		m.usage.mu.Unlock()
+	}()
+}
@@ -1,7 +1,9 @@
 package volume
 
 import (
+	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,6 +21,8 @@ var reAnonymousVolume = regexp.MustCompile(`^[a-f0-9]{64}$`)
 type Manager struct {
 	cli *client.Client
 	ctx context.Context
+
+	usage usageCache
 }
 
 func NewManager(cli *client.Client, ctx context.Context) *Manager {
@@ -32,6 +36,8 @@ type Volume struct {
 	Mount     string
 	Created   string
 	Scope     string
+	Size      string
+	RefCount  string
 	UsedBy    string
 	Anonymous bool
 }
@@ -46,7 +52,7 @@ func (v Volume) GetCells() []string {
 	if v.Anonymous {
 		anon = "Yes"
 	}
-	return []string{v.Name, v.Driver, v.Scope, v.UsedBy, v.Mount, v.Created, anon}
+	return []string{v.Name, v.Driver, v.Scope, v.Size, v.RefCount, v.UsedBy, v.Mount, v.Created, anon}
 }
 
 func (v Volume) GetStatusColor() (tcell.Color, tcell.Color) {
@@ -61,6 +67,10 @@ func (v Volume) GetColumnValue(column string) string {
 		return v.Driver
 	case "scope":
 		return v.Scope
+	case "size":
+		return v.Size
+	case "refcount":
+		return v.RefCount
 	case "mount":
 		return v.Mount
 	case "created":
@@ -118,23 +128,118 @@ func (m *Manager) List() ([]common.Resource, error) {
 			}
 		}
 
+		// Sizes come from the (slow) DiskUsage API, computed in the background
+		size, refs := "-", "-"
+		if u, ok := m.Usage(v.Name); ok {
+			if u.Size >= 0 {
+				size = common.FormatBytes(u.Size)
+			}
+			if u.RefCount >= 0 {
+				refs = strconv.FormatInt(u.RefCount, 10)
+			}
+		}
+
 		res = append(res, Volume{
 			Name:      v.Name,
 			Driver:    v.Driver,
 			Mount:     common.ShortenPath(v.Mountpoint),
 			Created:   created,
 			Scope:     v.Scope,
+			Size:      size,
+			RefCount:  refs,
 			Anonymous: IsAnonymousVolume(v.Name),
 		})
 	}
 	return res, nil
 }
 
-func (m *Manager) Create(name string) error {
-	_, err := m.cli.VolumeCreate(m.ctx, volTypes.CreateOptions{
-		Name: name,
-	})
-	return err
+// CreateSpec describes a volume to create, as typed in the create form
+type CreateSpec struct {
+	Name    string
+	Driver  string   // local when empty
+	Options []string // Driver options, key=value (e.g. type=nfs, o=addr=10.0.0.1,rw, device=:/export)
+	Labels  []string // key=value
+}
+
+var reVolumeName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+
+// Options accepted by the local driver (mount(8) type, options and device, plus size on some filesystems)
+var localDriverOptions = map[string]bool{"type": true, "o": true, "device": true, "size": true}
+
+// CreateOptions validates the spec and converts it into the API payload.
+func (s CreateSpec) CreateOptions() (volTypes.CreateOptions, error) {
+	opts := volTypes.CreateOptions{Name: strings.TrimSpace(s.Name), Driver: strings.TrimSpace(s.Driver)}
+	if opts.Name != "" && !reVolumeName.MatchString(opts.Name) {
+		return opts, fmt.Errorf("invalid volume name %q: only [a-zA-Z0-9][a-zA-Z0-9_.-] are allowed", opts.Name)
+	}
+	if opts.Driver == "" {
+		opts.Driver = "local"
+	}
+
+	opts.DriverOpts = make(map[string]string, len(s.Options))
+	for _, o := range s.Options {
+		k, v, ok := strings.Cut(o, "=")
+		if k = strings.TrimSpace(k); !ok || k == "" {
+			return opts, fmt.Errorf("invalid driver option %q (key=value)", o)
+		}
+		if opts.Driver == "local" && !localDriverOptions[k] {
+			return opts, fmt.Errorf("unknown option %q for the local driver (type, o, device, size)", k)
+		}
+		opts.DriverOpts[k] = strings.TrimSpace(v)
+	}
+	if opts.Driver == "local" && len(opts.DriverOpts) > 0 {
+		if opts.DriverOpts["device"] == "" && opts.DriverOpts["type"] != "tmpfs" {
+			return opts, fmt.Errorf("the local driver needs a device with type/o options")
+		}
+		if t := opts.DriverOpts["type"]; (t == "nfs" || t == "nfs4" || t == "cifs") && !strings.Contains(opts.DriverOpts["o"], "addr=") {
+			return opts, fmt.Errorf("%s volumes need the server address in o (o=addr=host,...)", t)
+		}
+	}
+
+	opts.Labels = make(map[string]string, len(s.Labels))
+	for _, l := range s.Labels {
+		k, v, _ := strings.Cut(l, "=")
+		if k = strings.TrimSpace(k); k == "" {
+			return opts, fmt.Errorf("invalid label: %q", l)
+		}
+		opts.Labels[k] = v
+	}
+	return opts, nil
+}
+
+// DockerCommand returns the equivalent docker volume create command line.
+func (s CreateSpec) DockerCommand() string {
+	args := []string{"docker", "volume", "create"}
+	if d := strings.TrimSpace(s.Driver); d != "" && d != "local" {
+		args = append(args, "--driver", d)
+	}
+	for _, o := range s.Options {
+		args = append(args, "--opt", common.ShellQuote(strings.TrimSpace(o)))
+	}
+	for _, l := range s.Labels {
+		args = append(args, "--label", common.ShellQuote(l))
+	}
+	if s.Name != "" {
+		args = append(args, common.ShellQuote(s.Name))
+	}
+	return strings.Join(args, " ")
+}
+
+// Create creates the volume and returns its name (generated when not set).
+// An existing name is rejected: the daemon would silently return that volume.
+func (m *Manager) Create(spec CreateSpec) (string, error) {
+	opts, err := spec.CreateOptions()
+	if err != nil {
+		return "", err
+	}
+	if opts.Name != "" && m.Exists(opts.Name) {
+		return "", fmt.Errorf("volume %s already exists", opts.Name)
+	}
+	v, err := m.cli.VolumeCreate(m.ctx, opts)
+	if err != nil {
+		return "", err
+	}
+	return v.Name, nil
 }
 
 func (m *Manager) Remove(id string, force bool) error {
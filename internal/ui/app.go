@@ -20,15 +20,18 @@ import (
 	"github.com/jr-k/d4s/internal/ui/dialogs"
 	"github.com/jr-k/d4s/internal/ui/styles"
 	"github.com/jr-k/d4s/internal/ui/views/aliases"
+	"github.com/jr-k/d4s/internal/ui/views/buildcache"
 	"github.com/jr-k/d4s/internal/ui/views/compose"
 	"github.com/jr-k/d4s/internal/ui/views/configs"
 	"github.com/jr-k/d4s/internal/ui/views/containers"
 	"github.com/jr-k/d4s/internal/ui/views/contexts"
+	"github.com/jr-k/d4s/internal/ui/views/diskusage"
 	"github.com/jr-k/d4s/internal/ui/views/images"
 	"github.com/jr-k/d4s/internal/ui/views/networks"
 	"github.com/jr-k/d4s/internal/ui/views/nodes"
 	"github.com/jr-k/d4s/internal/ui/views/plugins"
 	"github.com/jr-k/d4s/internal/ui/views/portforwards"
+	"github.com/jr-k/d4s/internal/ui/views/remotetags"
 	"github.com/jr-k/d4s/internal/ui/views/secrets"
 	"github.com/jr-k/d4s/internal/ui/views/services"
 	"github.com/jr-k/d4s/internal/ui/views/stacks"
@@ -433,6 +436,55 @@ func (a *App) initUI() {
 	}
 	a.Views[styles.TitlePortForwards] = vPortForwards
 
+	// DiskUsage
+	vDiskUsage := view.NewResourceView(a, styles.TitleDiskUsage)
+	vDiskUsage.ShortcutsFunc = diskusage.GetShortcuts
+	vDiskUsage.FetchFunc = diskusage.Fetch
+	vDiskUsage.Headers = diskusage.Headers
+	vDiskUsage.LazyLoad = true
+
+	// Default Sort: Size (Index 2) DESC
+	vDiskUsage.SortCol = 2
+	vDiskUsage.SortAsc = false
+
+	vDiskUsage.InputHandler = func(event *tcell.EventKey) *tcell.EventKey {
+		return diskusage.InputHandler(vDiskUsage, event)
+	}
+	a.Views[styles.TitleDiskUsage] = vDiskUsage
+
+	// BuildCache
+	vBuildCache := view.NewResourceView(a, styles.TitleBuildCache)
+	vBuildCache.ShortcutsFunc = buildcache.GetShortcuts
+	vBuildCache.FetchFunc = buildcache.Fetch
+	vBuildCache.InspectFunc = buildcache.Inspect
+	vBuildCache.RemoveFunc = buildcache.Remove
+	vBuildCache.Headers = buildcache.Headers
+
+	// Default Sort: Size (Index 2) DESC
+	vBuildCache.SortCol = 2
+	vBuildCache.SortAsc = false
+
+	vBuildCache.InputHandler = func(event *tcell.EventKey) *tcell.EventKey {
+		return buildcache.InputHandler(vBuildCache, event)
+	}
+	a.Views[styles.TitleBuildCache] = vBuildCache
+
+	// RemoteTags (scoped on an image repository from Images)
+	vRemoteTags := view.NewResourceView(a, styles.TitleRemoteTags)
+	vRemoteTags.ShortcutsFunc = remotetags.GetShortcuts
+	vRemoteTags.FetchFunc = remotetags.Fetch
+	vRemoteTags.Headers = remotetags.Headers
+	vRemoteTags.LazyLoad = true
+
+	// Default Sort: Pushed (Index 3) DESC
+	vRemoteTags.SortCol = 3
+	vRemoteTags.SortAsc = false
+
+	vRemoteTags.InputHandler = func(event *tcell.EventKey) *tcell.EventKey {
+		return remotetags.InputHandler(vRemoteTags, event)
+	}
+	a.Views[styles.TitleRemoteTags] = vRemoteTags
+
 	for title, view := range a.Views {
 		a.Pages.AddPage(title, view.Table, true, false)
 	}
@@ -721,8 +773,7 @@ func (a *App) SetActiveFilter(filter string) {
 				v.Refilter()
 
 				// Optimistically update the title count
-				count := len(v.Data)
-				title := a.formatViewTitle(page, fmt.Sprintf("%d", count), filter)
+				title := a.formatViewTitle(page, viewCount(v), filter)
 				a.updateViewTitle(v, title)
 			}
 		})
@@ -53,6 +53,10 @@ func (a *App) ExecuteCmd(cmd string) {
 		switchToRoot(styles.TitlePlugins)
 	case "w", "pf", "portforward", "portforwards":
 		switchToRoot(styles.TitlePortForwards)
+	case "du", "df", "diskusage":
+		switchToRoot(styles.TitleDiskUsage)
+	case "bc", "buildcache", "builder":
+		switchToRoot(styles.TitleBuildCache)
 	case "h", "help", "?":
 		a.Pages.AddPage("help", a.Help, true, true)
 	default:
@@ -34,6 +34,12 @@ func (a *App) RefreshCurrentView() {
 				}
 			case *inspect.StatsInspector:
 				actionName = "stats"
+			case *inspect.LiveInspector:
+				actionName = strings.ToLower(strings.SplitN(v.Action, " ", 2)[0])
+			case *inspect.TableInspector:
+				actionName = strings.ToLower(strings.SplitN(v.Action, " ", 2)[0])
+			case *inspect.FileBrowser:
+				actionName = "files"
 			}
 
 			status := ""
@@ -184,7 +190,7 @@ func (a *App) RefreshCurrentView() {
 				a.Flash.SetText(fmt.Sprintf("[%s]Error: %v", styles.TagError, err))
 			} else {
 				// Show actual title
-				title := a.formatViewTitle(page, fmt.Sprintf("%d", len(v.Data)), filter)
+				title := a.formatViewTitle(page, viewCount(v), filter)
 				a.updateViewTitle(v, title)
 				
 				v.Update(headers, data)
@@ -256,7 +262,7 @@ func (a *App) preloadViews() {
 		if title == initialView {
 			continue // Already being refreshed by StartAutoRefresh
 		}
-		if v.FetchFunc == nil {
+		if v.FetchFunc == nil || v.LazyLoad {
 			continue
 		}
 
@@ -281,14 +287,22 @@ func (a *App) preloadViews() {
 				v.CurrentScope = nil
 				v.Update(headers, data)
 
-				countStr := fmt.Sprintf("%d", len(v.Data))
-				viewTitle := a.formatViewTitle(title, countStr, "")
+				viewTitle := a.formatViewTitle(title, viewCount(v), "")
 				a.updateViewTitle(v, viewTitle)
 			})
 		}(title, v)
 	}
 }
 
+// viewCount returns the title counter of a view, with its optional extra info
+func viewCount(v *view.ResourceView) string {
+	count := fmt.Sprintf("%d", len(v.Data))
+	if v.TitleInfo != "" {
+		count += " · " + v.TitleInfo
+	}
+	return count
+}
+
 func (a *App) formatViewTitle(viewName string, countStr string, filter string) string {
 	viewName = strings.ToLower(viewName)
 	
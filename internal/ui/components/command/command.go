@@ -120,6 +120,8 @@ var availableCommands = []string{
 	"contexts",
 	"plugins",
 	"portforwards",
+	"diskusage",
+	"buildcache",
 	"help",
 	"aliases",
 	"q",
@@ -0,0 +1,54 @@
+package inspect
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jr-k/d4s/internal/dao"
+	"github.com/jr-k/d4s/internal/ui/styles"
+	"github.com/rivo/tview"
+)
+
+// NewBuildInspector follows the BuildKit output of an image build
+func NewBuildInspector(subject string, log *dao.BuildLog) *LiveInspector {
+	i := NewLiveInspector("Build image", subject, "text", func() (string, bool) {
+		return renderBuild(log)
+	})
+	i.Follow = true
+	return i
+}
+
+func renderBuild(l *dao.BuildLog) (string, bool) {
+	lines, dropped, done, err := l.Snapshot()
+	elapsed := l.Elapsed().Round(time.Second)
+
+	var sb strings.Builder
+	state := fmt.Sprintf("[%s]building (%s)[-]", styles.TagAccent, elapsed)
+	if err != nil {
+		state = fmt.Sprintf("[%s]failed after %s: %s[-]", styles.TagError, elapsed, tview.Escape(err.Error()))
+	} else if done {
+		state = fmt.Sprintf("[%s]built %.12s in %s[-]", styles.TagCyan, l.ImageID(), elapsed)
+	}
+	sb.WriteString(fmt.Sprintf(" %s\n", state))
+	if dropped > 0 {
+		sb.WriteString(fmt.Sprintf(" [%s]... %d earlier lines[-]\n", styles.TagDim, dropped))
+	}
+	sb.WriteString("\n")
+
+	for _, line := range lines {
+		escaped := tview.Escape(line)
+		switch {
+		case strings.Contains(line, "ERROR") || strings.HasPrefix(line, "error:"):
+			sb.WriteString(fmt.Sprintf(" [%s]%s[-]\n", styles.TagError, escaped))
+		case strings.HasPrefix(line, "#") && strings.Contains(line, " ["):
+			// Step header: #5 [2/4] RUN apk add ...
+			sb.WriteString(fmt.Sprintf(" [%s]%s[-]\n", styles.TagCyan, escaped))
+		case strings.HasSuffix(line, " DONE") || strings.HasSuffix(line, " CACHED") || strings.Contains(line, " DONE "):
+			sb.WriteString(fmt.Sprintf(" [%s]%s[-]\n", styles.TagDim, escaped))
+		default:
+			sb.WriteString(" " + escaped + "\n")
+		}
+	}
+	return sb.String(), done
+}
@@ -0,0 +1,67 @@
+package inspect
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jr-k/d4s/internal/dao"
+	daoCommon "github.com/jr-k/d4s/internal/dao/common"
+	"github.com/jr-k/d4s/internal/ui/styles"
+	"github.com/rivo/tview"
+)
+
+// NewCaptureInspector follows a packet capture, which stops when it is closed
+func NewCaptureInspector(subject string, opts dao.CaptureOptions, progress *dao.CopyProgress, stop func()) *LiveInspector {
+	i := NewLiveInspector("Capture packets", subject, "text", func() (string, bool) {
+		return renderCapture(opts, progress)
+	})
+	i.OnClose = stop
+	return i
+}
+
+func renderCapture(opts dao.CaptureOptions, p *dao.CopyProgress) (string, bool) {
+	current, _, _, done, err := p.Snapshot()
+	elapsed := p.Elapsed()
+
+	state := fmt.Sprintf("[%s]capturing (%s), esc to stop[-]", styles.TagAccent, elapsed.Round(time.Second))
+	if err != nil {
+		state = fmt.Sprintf("[%s]failed after %s: %s[-]", styles.TagError, elapsed.Round(time.Second), tview.Escape(err.Error()))
+	} else if done {
+		state = fmt.Sprintf("[%s]done in %s[-]", styles.TagCyan, elapsed.Round(time.Second))
+	}
+
+	iface, filter := opts.Interface, opts.Filter
+	if iface == "" {
+		iface = "any"
+	}
+	if filter == "" {
+		filter = "none"
+	}
+	var outputs []string
+	if opts.File != "" {
+		outputs = append(outputs, opts.File)
+	}
+	if opts.Wireshark {
+		outputs = append(outputs, "Wireshark")
+	}
+
+	rate := ""
+	if secs := elapsed.Seconds(); secs > 0 && current > 0 {
+		rate = fmt.Sprintf("  %s/s", daoCommon.FormatBytes(int64(float64(current)/secs)))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(" [%s]Interface:[-] %s\n", styles.TagDim, tview.Escape(iface)))
+	sb.WriteString(fmt.Sprintf(" [%s]Filter:[-]    %s\n", styles.TagDim, tview.Escape(filter)))
+	sb.WriteString(fmt.Sprintf(" [%s]Output:[-]    %s\n", styles.TagDim, tview.Escape(strings.Join(outputs, ", "))))
+	sb.WriteString(fmt.Sprintf(" [%s]Status:[-]    %s\n", styles.TagDim, state))
+	if opts.Duration > 0 {
+		sb.WriteString(fmt.Sprintf(" [%s]Duration:[-]  %s %s / %s\n", styles.TagDim,
+			progressBar(int64(min(elapsed, opts.Duration)), int64(opts.Duration), 30),
+			elapsed.Round(time.Second), opts.Duration))
+	}
+	sb.WriteString(fmt.Sprintf(" [%s]Captured:[-]  %s%s\n", styles.TagDim, daoCommon.FormatBytes(current), rate))
+
+	return sb.String(), done
+}
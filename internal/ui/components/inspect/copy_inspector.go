@@ -0,0 +1,53 @@
+package inspect
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jr-k/d4s/internal/dao"
+	daoCommon "github.com/jr-k/d4s/internal/dao/common"
+	"github.com/jr-k/d4s/internal/ui/styles"
+	"github.com/rivo/tview"
+)
+
+// NewCopyInspector follows a file transfer between this machine and a container
+func NewCopyInspector(action, subject string, progress *dao.CopyProgress) *LiveInspector {
+	return NewLiveInspector(action, subject, "text", func() (string, bool) {
+		return renderCopy(progress)
+	})
+}
+
+func renderCopy(p *dao.CopyProgress) (string, bool) {
+	current, total, files, done, err := p.Snapshot()
+	elapsed := p.Elapsed()
+
+	state := fmt.Sprintf("[%s]in progress (%s)[-]", styles.TagAccent, elapsed.Round(time.Second))
+	if err != nil {
+		state = fmt.Sprintf("[%s]failed after %s: %s[-]", styles.TagError, elapsed.Round(time.Second), tview.Escape(err.Error()))
+	} else if done {
+		state = fmt.Sprintf("[%s]done in %s[-]", styles.TagCyan, elapsed.Round(time.Second))
+	}
+
+	rate := ""
+	if secs := elapsed.Seconds(); secs > 0 && current > 0 {
+		rate = fmt.Sprintf("  %s/s", daoCommon.FormatBytes(int64(float64(current)/secs)))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(" [%s]Source:[-]      %s\n", styles.TagDim, tview.Escape(p.Source)))
+	sb.WriteString(fmt.Sprintf(" [%s]Destination:[-] %s\n", styles.TagDim, tview.Escape(p.Destination)))
+	sb.WriteString(fmt.Sprintf(" [%s]Status:[-]      %s\n", styles.TagDim, state))
+	if total > 0 {
+		sb.WriteString(fmt.Sprintf(" [%s]Progress:[-]    %s %3d%%  %s / %s%s\n", styles.TagDim,
+			progressBar(current, total, 30), min(current*100/total, 100),
+			daoCommon.FormatBytes(current), daoCommon.FormatBytes(total), rate))
+	} else {
+		sb.WriteString(fmt.Sprintf(" [%s]Progress:[-]    %s%s\n", styles.TagDim, daoCommon.FormatBytes(current), rate))
+	}
+	if files > 0 {
+		sb.WriteString(fmt.Sprintf(" [%s]Files:[-]       %d\n", styles.TagDim, files))
+	}
+
+	return sb.String(), done
+}
@@ -0,0 +1,92 @@
+package inspect
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jr-k/d4s/internal/dao"
+	"github.com/jr-k/d4s/internal/ui/styles"
+	"github.com/rivo/tview"
+)
+
+// NewDiagnoseInspector shows a connectivity check from a container, filled by
+// the caller with RenderDiagnosis once the helper is done
+func NewDiagnoseInspector(subject, target string) *TextInspector {
+	return NewTextInspector("Diagnose", subject, fmt.Sprintf(" [%s]Checking %s...\n", styles.TagAccent, tview.Escape(target)), "text")
+}
+
+// RenderDiagnosis lists the resolver, the resolved addresses and the TCP
+// connection result, with a hint for the usual causes of failure.
+func RenderDiagnosis(source string, d *dao.Diagnosis) string {
+	ok := func(s string) string { return fmt.Sprintf("[%s]%s[-]", styles.TagInfo, s) }
+	bad := func(s string) string { return fmt.Sprintf("[%s]%s[-]", styles.TagError, tview.Escape(s)) }
+	ms := func(t time.Duration) string { return fmt.Sprintf("%.1f ms", float64(t.Microseconds())/1000) }
+
+	target := d.Host
+	if d.Port > 0 {
+		target = fmt.Sprintf("%s:%d", d.Host, d.Port)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(" [%s]From:[-]     %s\n", styles.TagDim, tview.Escape(source)))
+	sb.WriteString(fmt.Sprintf(" [%s]To:[-]       %s\n", styles.TagDim, tview.Escape(target)))
+	resolver := strings.Join(d.Nameservers, ", ")
+	if len(d.Search) > 0 {
+		resolver += "  search " + strings.Join(d.Search, " ")
+	}
+	sb.WriteString(fmt.Sprintf(" [%s]Resolver:[-] %s\n\n", styles.TagDim, tview.Escape(resolver)))
+
+	if len(d.Lookups) > 0 || len(d.Hosts) > 0 {
+		sb.WriteString(fmt.Sprintf(" [%s::b]DNS[-::-]\n", styles.TagCyan))
+		if len(d.Hosts) > 0 {
+			sb.WriteString(fmt.Sprintf("   /etc/hosts  %s\n", ok(strings.Join(d.Hosts, ", "))))
+		}
+		for _, l := range d.Lookups {
+			var result string
+			switch {
+			case l.Error != "":
+				result = bad(l.Error)
+			case len(l.Addresses) > 0:
+				result = ok(strings.Join(l.Addresses, ", "))
+			case l.Status != "" && l.Status != "NOERROR":
+				result = bad(l.Status)
+			default:
+				result = fmt.Sprintf("[%s]no record[-]", styles.TagDim)
+			}
+			line := fmt.Sprintf("   %-4s        %s", l.Type, result)
+			if l.Time > 0 || l.Error == "" {
+				line += fmt.Sprintf("  [%s]%s[-]", styles.TagDim, ms(l.Time))
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if d.Port > 0 {
+		sb.WriteString(fmt.Sprintf(" [%s::b]TCP[-::-]\n", styles.TagCyan))
+		switch {
+		case d.Connected:
+			sb.WriteString(fmt.Sprintf("   %s to %s  [%s]%s[-]\n", ok("connected"), tview.Escape(d.RemoteIP), styles.TagDim, ms(d.ConnectTime)))
+		case d.TCPError != "":
+			sb.WriteString(fmt.Sprintf("   %s\n", bad(d.TCPError)))
+		default:
+			sb.WriteString(fmt.Sprintf("   %s\n", bad("no connection")))
+		}
+		sb.WriteString("\n")
+	}
+
+	var hint string
+	switch {
+	case !d.Resolved():
+		hint = fmt.Sprintf("%s does not resolve: container and service names only resolve on a user-defined network shared with %s.", d.Host, source)
+	case d.Port > 0 && strings.Contains(d.TCPError, "refused"):
+		hint = "The host is reachable but nothing listens on this port (check the port and that the service binds 0.0.0.0, not 127.0.0.1)."
+	case d.Port > 0 && !d.Connected:
+		hint = "No answer: a firewall, an internal network or a stopped container can drop the traffic."
+	}
+	if hint != "" {
+		sb.WriteString(fmt.Sprintf(" [%s]%s[-]\n", styles.TagAccent, tview.Escape(hint)))
+	}
+	return sb.String()
+}
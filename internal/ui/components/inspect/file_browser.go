@@ -0,0 +1,430 @@
+package inspect
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/atotto/clipboard"
+	"github.com/gdamore/tcell/v2"
+	"github.com/jr-k/d4s/internal/dao"
+	daoCommon "github.com/jr-k/d4s/internal/dao/common"
+	"github.com/jr-k/d4s/internal/ui/common"
+	"github.com/jr-k/d4s/internal/ui/styles"
+	"github.com/rivo/tview"
+)
+
+// Files larger than this are previewed partially
+const previewLimit = 512 * 1024
+
+// FileBrowser navigates the filesystem of a container (or of a volume through
+// a helper container) and previews text files with syntax highlighting.
+type FileBrowser struct {
+	App    common.AppController
+	Pages  *tview.Pages
+	Table  *tview.Table
+	Viewer *TextViewer
+
+	Action      string
+	Subject     string
+	ContainerID string
+	Root        string // Top directory, shown as "/" when not the filesystem root
+
+	// Optional cleanup when closing (e.g. remove the volume helper)
+	OnClose func()
+
+	dir     string
+	tree    *dao.FileTree
+	rows    []dao.FileEntry
+	filter  string
+	message string
+
+	previewing  bool
+	previewPath string
+	previewLang string
+	previewInfo string
+}
+
+// Ensure FileBrowser implements common.Inspector
+var _ common.Inspector = (*FileBrowser)(nil)
+
+func NewFileBrowser(action, subject, containerID, root string) *FileBrowser {
+	if root == "" {
+		root = "/"
+	}
+	return &FileBrowser{
+		Action:      action,
+		Subject:     subject,
+		ContainerID: containerID,
+		Root:        root,
+		dir:         root,
+	}
+}
+
+func (b *FileBrowser) GetID() string {
+	return "inspect"
+}
+
+func (b *FileBrowser) GetPrimitive() tview.Primitive {
+	return b.Pages
+}
+
+// display returns the path relative to Root
+func (b *FileBrowser) display(p string) string {
+	if b.Root == "/" {
+		return p
+	}
+	return "/" + strings.TrimPrefix(strings.TrimPrefix(p, b.Root), "/")
+}
+
+func (b *FileBrowser) GetTitle() string {
+	if b.previewing {
+		filter, idx, count := b.Viewer.GetSearchInfo()
+		mode := b.previewLang
+		if b.previewInfo != "" {
+			mode += " " + b.previewInfo
+		}
+		return FormatInspectorTitle(b.Action, b.Subject+"@"+b.display(b.previewPath), mode, filter, idx, count)
+	}
+
+	title := FormatInspectorTitle(b.Action, b.Subject+"@"+b.display(b.dir), fmt.Sprintf("%d", len(b.rows)), "", 0, 0)
+	if b.filter != "" {
+		title = strings.TrimSuffix(title, " ") + fmt.Sprintf(" [%s::b]</[%s]%s[%s]>[-::-] ", styles.TagCyan, styles.TagPink, b.filter, styles.TagCyan)
+	}
+	return title
+}
+
+func (b *FileBrowser) GetShortcuts() []string {
+	if b.previewing {
+		return []string{
+			common.FormatSCHeader("esc", "Back"),
+			common.FormatSCHeader("c", "Copy"),
+			common.FormatSCHeader("/", "Search"),
+			common.FormatSCHeader("n/p", "Next/Prev"),
+		}
+	}
+	return []string{
+		common.FormatSCHeader("esc", "Close"),
+		common.FormatSCHeader("enter", "Open"),
+		common.FormatSCHeader("backspace", "Up"),
+		common.FormatSCHeader("/", "Search Name"),
+		common.FormatSCHeader("c", "Copy Path"),
+		common.FormatSCHeader("r", "Reload"),
+	}
+}
+
+func (b *FileBrowser) OnMount(app common.AppController) {
+	b.App = app
+
+	b.Table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+	b.Table.SetBorder(true).
+		SetTitleColor(styles.ColorTitle).
+		SetBorderColor(styles.ColorTableBorder)
+	b.Table.SetBackgroundColor(styles.ColorBg)
+	b.Table.SetSelectedStyle(tcell.StyleDefault.Foreground(styles.ColorSelectFg).Background(styles.ColorSelectBg))
+
+	b.Viewer = NewTextViewer(app)
+	b.Viewer.View.SetBorder(true).SetTitleColor(styles.ColorTitle)
+	b.Viewer.TitleUpdateFunc = func() {
+		b.Viewer.View.SetTitle(b.GetTitle())
+	}
+
+	b.Pages = tview.NewPages().
+		AddPage("list", b.Table, true, true).
+		AddPage("preview", b.Viewer.View, true, false)
+
+	b.load(b.Root, "")
+}
+
+func (b *FileBrowser) OnUnmount() {
+	if b.OnClose != nil {
+		go b.OnClose()
+	}
+}
+
+func (b *FileBrowser) ApplyFilter(filter string) {
+	if b.previewing {
+		b.Viewer.ApplyFilter(filter)
+		return
+	}
+	b.filter = filter
+	b.render("")
+}
+
+func (b *FileBrowser) InputHandler(event *tcell.EventKey) *tcell.EventKey {
+	if b.previewing {
+		if event.Key() == tcell.KeyEsc {
+			b.closePreview()
+			return nil
+		}
+		if b.Viewer.InputHandler(event) {
+			return nil
+		}
+		return event
+	}
+
+	switch event.Key() {
+	case tcell.KeyEsc:
+		b.App.CloseInspector()
+		return nil
+	case tcell.KeyEnter:
+		if e, ok := b.selected(); ok {
+			b.open(e)
+		}
+		return nil
+	case tcell.KeyBackspace, tcell.KeyBackspace2, tcell.KeyLeft:
+		b.up()
+		return nil
+	}
+
+	switch event.Rune() {
+	case '/':
+		b.App.ActivateCmd("/")
+		return nil
+	case 'c':
+		if e, ok := b.selected(); ok && e.Path != "" {
+			b.copyPath(e.Path)
+		}
+		return nil
+	case 'r':
+		b.tree = nil
+		b.load(b.dir, "")
+		return nil
+	}
+	return event
+}
+
+// load shows dir, from the current index when it is complete, then selects focus
+func (b *FileBrowser) load(dir, focus string) {
+	if b.tree != nil && b.tree.Complete(dir) {
+		b.dir = dir
+		b.render(focus)
+		return
+	}
+
+	b.message = fmt.Sprintf("[%s]Loading %s...", styles.TagAccent, tview.Escape(b.display(dir)))
+	b.render("")
+
+	b.App.RunInBackground(func() {
+		tree, err := b.App.GetDocker().ListContainerFiles(b.ContainerID, dir)
+		b.App.GetTviewApp().QueueUpdateDraw(func() {
+			if err != nil {
+				b.message = fmt.Sprintf("[%s]%s", styles.TagError, tview.Escape(err.Error()))
+				b.render("")
+				return
+			}
+			b.tree, b.dir, b.message = tree, dir, ""
+			if tree.Truncated && tree.Partial[dir] {
+				b.message = fmt.Sprintf("[%s]Listing truncated, open a subdirectory to see all of its files", styles.TagDim)
+			}
+			b.render(focus)
+		})
+	})
+}
+
+func (b *FileBrowser) up() {
+	if b.dir == b.Root {
+		return
+	}
+	b.filter = ""
+	b.load(path.Dir(b.dir), b.dir)
+}
+
+func (b *FileBrowser) open(e dao.FileEntry) {
+	if e.Name == ".." {
+		b.up()
+		return
+	}
+	if e.Dir {
+		b.filter = ""
+		b.load(e.Path, "")
+		return
+	}
+	if e.Link == "" {
+		b.preview(e.Path)
+		return
+	}
+
+	// Symlink: browse its target when it is a directory, preview it otherwise
+	target := e.Link
+	if !path.IsAbs(target) {
+		target = path.Join(path.Dir(e.Path), target)
+	}
+	if b.Root != "/" && target != b.Root && !strings.HasPrefix(target, b.Root+"/") {
+		b.App.SetFlashError(fmt.Sprintf("%s points outside of %s", e.Name, b.Subject))
+		return
+	}
+	b.App.RunInBackground(func() {
+		tree, err := b.App.GetDocker().ListContainerFiles(b.ContainerID, target)
+		b.App.GetTviewApp().QueueUpdateDraw(func() {
+			switch {
+			case errors.Is(err, dao.ErrNotDirectory):
+				b.preview(target)
+			case err != nil:
+				b.App.SetFlashError(err.Error())
+			default:
+				b.filter = ""
+				b.tree, b.dir, b.message = tree, target, ""
+				b.render("")
+			}
+		})
+	})
+}
+
+func (b *FileBrowser) selected() (dao.FileEntry, bool) {
+	row, _ := b.Table.GetSelection()
+	if row < 1 || row > len(b.rows) {
+		return dao.FileEntry{}, false
+	}
+	return b.rows[row-1], true
+}
+
+func (b *FileBrowser) render(focus string) {
+	if b.Table == nil {
+		return
+	}
+	b.Table.Clear()
+
+	for col, h := range []string{"NAME", "SIZE", "MODE", "MODIFIED"} {
+		b.Table.SetCell(0, col, tview.NewTableCell(" "+h+" ").
+			SetTextColor(styles.ColorHeader).
+			SetBackgroundColor(styles.ColorBg).
+			SetSelectable(false).
+			SetExpansion(1))
+	}
+
+	b.rows = nil
+	if b.tree != nil {
+		if b.filter != "" {
+			// Search by name in the whole indexed subtree
+			filter := strings.ToLower(b.filter)
+			b.tree.Walk(b.dir, func(e dao.FileEntry) {
+				if strings.Contains(strings.ToLower(e.Name), filter) {
+					b.rows = append(b.rows, e)
+				}
+			})
+		} else {
+			if b.dir != b.Root {
+				b.rows = append(b.rows, dao.FileEntry{Name: "..", Dir: true})
+			}
+			b.rows = append(b.rows, b.tree.Dirs[b.dir]...)
+		}
+	}
+
+	selectRow := 1
+	for r, e := range b.rows {
+		name := e.Name
+		if b.filter != "" {
+			name = strings.TrimPrefix(e.Path, strings.TrimSuffix(b.dir, "/")+"/")
+		}
+		color := styles.ColorFg
+		size := daoCommon.FormatBytes(e.Size)
+		switch {
+		case e.Dir:
+			name += "/"
+			color = styles.ColorAccent
+			size = "-"
+		case e.Link != "":
+			name += " -> " + e.Link
+			color = styles.ColorStatusBlue
+			size = "-"
+		}
+		modified := "-"
+		if !e.ModTime.IsZero() {
+			modified = daoCommon.FormatTime(e.ModTime.Unix())
+		}
+		mode := "-"
+		if e.Name != ".." {
+			mode = e.Mode.String()
+		}
+
+		for col, text := range []string{name, size, mode, modified} {
+			cell := tview.NewTableCell(" " + tview.Escape(text) + " ").SetTextColor(styles.ColorFg)
+			if col == 0 {
+				cell.SetTextColor(color).SetMaxWidth(80)
+			}
+			b.Table.SetCell(r+1, col, cell)
+		}
+		if focus != "" && e.Path == focus {
+			selectRow = r + 1
+		}
+	}
+
+	if b.message != "" {
+		b.Table.SetCell(len(b.rows)+2, 0, tview.NewTableCell(" "+b.message).SetSelectable(false))
+	}
+
+	if len(b.rows) > 0 {
+		b.Table.Select(selectRow, 0)
+	}
+	b.Table.SetTitle(b.GetTitle())
+}
+
+func (b *FileBrowser) preview(file string) {
+	b.previewing = true
+	b.previewPath = file
+	b.previewLang = "text"
+	b.previewInfo = ""
+	b.Viewer.Search.ApplyFilter("")
+	b.Viewer.Update(fmt.Sprintf(" [%s]Loading %s...\n", styles.TagAccent, tview.Escape(b.display(file))), "text")
+	b.Viewer.View.SetTitle(b.GetTitle())
+	b.Pages.SwitchToPage("preview")
+	b.App.GetTviewApp().SetFocus(b.Viewer.View)
+	b.App.UpdateShortcuts()
+
+	b.App.RunInBackground(func() {
+		data, size, err := b.App.GetDocker().ReadContainerFile(b.ContainerID, file, previewLimit)
+		b.App.GetTviewApp().QueueUpdateDraw(func() {
+			if !b.previewing || b.previewPath != file {
+				return
+			}
+			switch {
+			case err != nil:
+				b.Viewer.Update(fmt.Sprintf("Error: %v", err), "text")
+			case bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0:
+				b.Viewer.Update(fmt.Sprintf(" [%s]Binary file (%s), no preview\n", styles.TagDim, daoCommon.FormatBytes(size)), "text")
+			default:
+				b.previewLang = detectLang(path.Base(file), data)
+				if int64(len(data)) < size {
+					b.previewInfo = fmt.Sprintf("first %s of %s", daoCommon.FormatBytes(int64(len(data))), daoCommon.FormatBytes(size))
+				}
+				b.Viewer.Update(string(data), b.previewLang)
+			}
+			b.Viewer.View.SetTitle(b.GetTitle())
+		})
+	})
+}
+
+func (b *FileBrowser) closePreview() {
+	b.previewing = false
+	b.Pages.SwitchToPage("list")
+	b.App.GetTviewApp().SetFocus(b.Table)
+	b.App.UpdateShortcuts()
+	b.Table.SetTitle(b.GetTitle())
+}
+
+// detectLang picks the chroma lexer from the file name, then the content
+func detectLang(name string, data []byte) string {
+	lexer := lexers.Match(name)
+	if lexer == nil {
+		lexer = lexers.Analyse(string(data))
+	}
+	if lexer == nil {
+		return "text"
+	}
+	return strings.ToLower(lexer.Config().Name)
+}
+
+func (b *FileBrowser) copyPath(p string) {
+	if err := clipboard.WriteAll(p); err != nil {
+		b.App.AppendFlashError(fmt.Sprintf("%v", err))
+	} else {
+		b.App.AppendFlashSuccess(fmt.Sprintf("copied %s", p))
+	}
+}
@@ -0,0 +1,75 @@
+package inspect
+
+import (
+	"time"
+
+	"github.com/jr-k/d4s/internal/ui/common"
+)
+
+// LiveInspector is a TextInspector whose content is regenerated periodically
+// (progress of pulls/pushes, long running scans...) until Render reports done.
+type LiveInspector struct {
+	TextInspector
+
+	Render   func() (content string, done bool)
+	Interval time.Duration
+	Follow   bool // Stay scrolled to the end (log output)
+	OnClose  func()
+
+	stopChan chan struct{}
+}
+
+// Ensure LiveInspector implements common.Inspector
+var _ common.Inspector = (*LiveInspector)(nil)
+
+func NewLiveInspector(action, subject, lang string, render func() (string, bool)) *LiveInspector {
+	content, _ := render()
+	return &LiveInspector{
+		TextInspector: TextInspector{
+			Action:  action,
+			Subject: subject,
+			Content: content,
+			Lang:    lang,
+		},
+		Render:   render,
+		Interval: 500 * time.Millisecond,
+		stopChan: make(chan struct{}),
+	}
+}
+
+func (i *LiveInspector) OnMount(app common.AppController) {
+	i.TextInspector.OnMount(app)
+	i.Viewer.Follow = i.Follow
+
+	go func() {
+		ticker := time.NewTicker(i.Interval)
+		defer ticker.Stop()
+
+		last := i.Content
+		for {
+			select {
+			case <-ticker.C:
+				content, done := i.Render()
+				if content != last {
+					last = content
+					app.GetTviewApp().QueueUpdateDraw(func() {
+						i.Content = content
+						i.Viewer.Update(content, i.Lang)
+					})
+				}
+				if done {
+					return
+				}
+			case <-i.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+func (i *LiveInspector) OnUnmount() {
+	close(i.stopChan)
+	if i.OnClose != nil {
+		i.OnClose()
+	}
+}
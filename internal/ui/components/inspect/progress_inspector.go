@@ -0,0 +1,90 @@
+package inspect
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jr-k/d4s/internal/dao"
+	daoCommon "github.com/jr-k/d4s/internal/dao/common"
+	"github.com/jr-k/d4s/internal/ui/styles"
+	"github.com/rivo/tview"
+)
+
+// NewProgressInspector shows the per-layer progress of an image pull/push
+func NewProgressInspector(action, subject string, progress *dao.ImageProgress) *LiveInspector {
+	return NewLiveInspector(action, subject, "text", func() (string, bool) {
+		return renderProgress(progress)
+	})
+}
+
+func renderProgress(p *dao.ImageProgress) (string, bool) {
+	layers, messages, done, err := p.Snapshot()
+	current, total := p.Bytes()
+	elapsed := p.Elapsed().Round(time.Second)
+
+	var sb strings.Builder
+
+	state := fmt.Sprintf("[%s]in progress (%s)[-]", styles.TagAccent, elapsed)
+	if err != nil {
+		state = fmt.Sprintf("[%s]failed after %s: %s[-]", styles.TagError, elapsed, tview.Escape(err.Error()))
+	} else if done {
+		state = fmt.Sprintf("[%s]done in %s[-]", styles.TagCyan, elapsed)
+	}
+
+	finished := 0
+	for _, l := range layers {
+		if l.Done() {
+			finished++
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf(" [%s]Reference:[-] %s\n", styles.TagDim, p.Ref))
+	sb.WriteString(fmt.Sprintf(" [%s]Status:[-]    %s\n", styles.TagDim, state))
+	if total > 0 {
+		sb.WriteString(fmt.Sprintf(" [%s]Progress:[-]  %s %3d%%  %s / %s  (%d/%d layers)\n", styles.TagDim,
+			progressBar(current, total, 30), current*100/total,
+			daoCommon.FormatBytes(current), daoCommon.FormatBytes(total), finished, len(layers)))
+	} else {
+		sb.WriteString(fmt.Sprintf(" [%s]Progress:[-]  %d/%d layers\n", styles.TagDim, finished, len(layers)))
+	}
+
+	if len(layers) > 0 {
+		sb.WriteString(fmt.Sprintf("\n [%s::b]%-14s %-22s %s[-::-]\n", styles.TagCyan, "LAYER", "STATUS", "PROGRESS"))
+		for _, l := range layers {
+			color := styles.TagFg
+			if l.Done() {
+				color = styles.TagDim
+			} else if l.Total > 0 {
+				color = styles.TagAccent
+			}
+
+			detail := ""
+			if l.Total > 0 && !l.Done() {
+				detail = fmt.Sprintf("%s %s / %s", progressBar(l.Current, l.Total, 20),
+					daoCommon.FormatBytes(l.Current), daoCommon.FormatBytes(l.Total))
+			} else if l.Total > 0 {
+				detail = daoCommon.FormatBytes(l.Total)
+			}
+			sb.WriteString(fmt.Sprintf(" [%s]%-14s %-22s %s[-]\n", color, l.ID, l.Status, detail))
+		}
+	}
+
+	if len(messages) > 0 {
+		sb.WriteString(fmt.Sprintf("\n [%s::b]MESSAGES[-::-]\n", styles.TagCyan))
+		for _, m := range messages {
+			sb.WriteString(" " + tview.Escape(m) + "\n")
+		}
+	}
+
+	return sb.String(), done
+}
+
+func progressBar(current, total int64, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = int(current * int64(width) / total)
+	}
+	filled = max(0, min(filled, width))
+	return fmt.Sprintf("[%s]%s[%s]%s[-]", styles.TagCyan, strings.Repeat("█", filled), styles.TagDim, strings.Repeat("░", width-filled))
+}
@@ -0,0 +1,201 @@
+package inspect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/gdamore/tcell/v2"
+	"github.com/jr-k/d4s/internal/ui/common"
+	"github.com/jr-k/d4s/internal/ui/styles"
+	"github.com/rivo/tview"
+)
+
+// TableInspector displays rows in a table, the search bar filters the rows
+type TableInspector struct {
+	App   common.AppController
+	Table *tview.Table
+
+	Action  string
+	Subject string
+	Headers []string
+
+	// Optional extra keys, KeyHandler returns true when it handled the event
+	Shortcuts  []string
+	KeyHandler func(event *tcell.EventKey) bool
+
+	rows    [][]string
+	visible int
+	filter  string
+	message string
+}
+
+// Ensure TableInspector implements common.Inspector
+var _ common.Inspector = (*TableInspector)(nil)
+
+func NewTableInspector(action, subject string, headers []string, message string) *TableInspector {
+	return &TableInspector{
+		Action:  action,
+		Subject: subject,
+		Headers: headers,
+		message: message,
+	}
+}
+
+func (i *TableInspector) GetID() string {
+	return "inspect"
+}
+
+func (i *TableInspector) GetPrimitive() tview.Primitive {
+	return i.Table
+}
+
+func (i *TableInspector) GetTitle() string {
+	mode := fmt.Sprintf("%d", len(i.rows))
+	if i.filter != "" {
+		mode = fmt.Sprintf("%d/%d", i.visible, len(i.rows))
+	}
+	title := FormatInspectorTitle(i.Action, i.Subject, mode, "", 0, 0)
+	if i.filter != "" {
+		title = strings.TrimSuffix(title, " ") + fmt.Sprintf(" [%s::b]</[%s]%s[%s]>[-::-] ", styles.TagCyan, styles.TagPink, i.filter, styles.TagCyan)
+	}
+	return title
+}
+
+func (i *TableInspector) GetShortcuts() []string {
+	shortcuts := []string{
+		common.FormatSCHeader("esc", "Close"),
+		common.FormatSCHeader("c", "Copy"),
+		common.FormatSCHeader("/", "Filter"),
+	}
+	return append(shortcuts, i.Shortcuts...)
+}
+
+func (i *TableInspector) OnMount(app common.AppController) {
+	i.App = app
+	i.Table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+	i.Table.SetBorder(true).
+		SetTitleColor(styles.ColorTitle).
+		SetBorderColor(styles.ColorTableBorder)
+	i.Table.SetBackgroundColor(styles.ColorBg)
+	i.Table.SetSelectedStyle(tcell.StyleDefault.Foreground(styles.ColorSelectFg).Background(styles.ColorSelectBg))
+	i.render()
+}
+
+func (i *TableInspector) OnUnmount() {}
+
+// SetRows replaces the rows (call from the UI goroutine).
+func (i *TableInspector) SetRows(rows [][]string) {
+	i.rows = rows
+	i.message = ""
+	i.render()
+}
+
+// SetMessage shows a single line (loading, error) instead of the rows.
+func (i *TableInspector) SetMessage(message string) {
+	i.rows = nil
+	i.message = message
+	i.render()
+}
+
+func (i *TableInspector) ApplyFilter(filter string) {
+	i.filter = filter
+	i.render()
+}
+
+func (i *TableInspector) InputHandler(event *tcell.EventKey) *tcell.EventKey {
+	if event.Key() == tcell.KeyEsc {
+		i.App.CloseInspector()
+		return nil
+	}
+	if i.KeyHandler != nil && i.KeyHandler(event) {
+		return nil
+	}
+
+	switch event.Rune() {
+	case 'c':
+		i.copyToClipboard()
+		return nil
+	case '/':
+		i.App.ActivateCmd("/")
+		return nil
+	}
+	return event
+}
+
+func (i *TableInspector) matches(row []string) bool {
+	if i.filter == "" {
+		return true
+	}
+	filter := strings.ToLower(i.filter)
+	for _, cell := range row {
+		if strings.Contains(strings.ToLower(cell), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// VisibleRows returns the rows matching the current filter.
+func (i *TableInspector) VisibleRows() [][]string {
+	var out [][]string
+	for _, row := range i.rows {
+		if i.matches(row) {
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+func (i *TableInspector) render() {
+	if i.Table == nil {
+		return
+	}
+	i.Table.Clear()
+
+	for col, h := range i.Headers {
+		i.Table.SetCell(0, col, tview.NewTableCell(" "+h+" ").
+			SetTextColor(styles.ColorHeader).
+			SetBackgroundColor(styles.ColorBg).
+			SetSelectable(false).
+			SetExpansion(1))
+	}
+
+	if i.message != "" {
+		i.Table.SetCell(2, 0, tview.NewTableCell(i.message).
+			SetTextColor(styles.ColorAccent).
+			SetSelectable(false))
+	}
+
+	rows := i.VisibleRows()
+	i.visible = len(rows)
+	for r, row := range rows {
+		for col, text := range row {
+			i.Table.SetCell(r+1, col, tview.NewTableCell(" "+tview.Escape(text)+" ").
+				SetTextColor(styles.ColorFg).
+				SetMaxWidth(60))
+		}
+	}
+	if len(rows) > 0 {
+		i.Table.Select(1, 0)
+	}
+	i.Table.ScrollToBeginning()
+	i.Table.SetTitle(i.GetTitle())
+}
+
+func (i *TableInspector) copyToClipboard() {
+	var sb strings.Builder
+	sb.WriteString(strings.Join(i.Headers, "\t") + "\n")
+	for _, row := range i.VisibleRows() {
+		sb.WriteString(strings.Join(row, "\t") + "\n")
+	}
+	content := sb.String()
+	if err := clipboard.WriteAll(content); err != nil {
+		i.App.AppendFlashError(fmt.Sprintf("%v", err))
+	} else {
+		i.App.AppendFlashSuccess(fmt.Sprintf("copied %d rows", i.visible))
+	}
+}
@@ -1,6 +1,8 @@
 package inspect
 
 import (
+	"strings"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/jr-k/d4s/internal/ui/common"
 	"github.com/jr-k/d4s/internal/ui/styles"
@@ -16,6 +18,11 @@ type TextInspector struct {
 	Subject string
 	Content string
 	Lang    string
+
+	// Optional summary shown in its own box above the content (not copied)
+	HeaderTitle string
+	Header      string
+	layout      *tview.Flex
 }
 
 // Ensure TextInspector implements common.Inspector
@@ -35,6 +42,9 @@ func (i *TextInspector) GetID() string {
 }
 
 func (i *TextInspector) GetPrimitive() tview.Primitive {
+	if i.layout != nil {
+		return i.layout
+	}
 	return i.Viewer.GetPrimitive()
 }
 
@@ -67,6 +77,21 @@ func (i *TextInspector) OnMount(app common.AppController) {
 	i.Viewer.TitleUpdateFunc = func() {
 		tv.SetTitle(i.GetTitle())
 	}
+
+	if i.Header != "" {
+		header := strings.TrimRight(i.Header, "\n")
+		box := tview.NewTextView().
+			SetDynamicColors(true).
+			SetText(header)
+		box.SetBorder(true).
+			SetTitle(" " + i.HeaderTitle + " ").
+			SetTitleColor(styles.ColorTitle).
+			SetBorderColor(styles.ColorTableBorder)
+		box.SetBackgroundColor(styles.ColorBg)
+		i.layout = tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(box, strings.Count(header, "\n")+3, 0, false).
+			AddItem(i.Viewer.GetPrimitive(), 0, 1, true)
+	}
 }
 
 func (i *TextInspector) OnUnmount() {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"regexp"
+	"strings"
 	"sync"
 
 	"github.com/alecthomas/chroma/v2/quick"
@@ -24,6 +25,7 @@ type TextViewer struct {
 	Search *SearchController
 	App    common.AppController
 	TitleUpdateFunc func() // Optional callback to update parent title on navigation
+	Follow          bool   // Keep the view scrolled to the end on updates while it is there (logs)
 
 	// Content State
 	content string
@@ -83,6 +85,9 @@ func (t *TextViewer) refresh() {
 				return
 			}
 
+			// Only follow when the end was visible, not while reading earlier lines
+			follow := t.Follow && t.atEnd()
+
 			// Capture current scroll before overwriting text
 			if t.View.GetText(false) != "" {
 				r, c := t.View.GetScrollOffset()
@@ -104,10 +109,14 @@ func (t *TextViewer) refresh() {
 			} else {
 				// No matches, clear highlight and restore scroll
 				t.View.Highlight()
-				t.mu.Lock()
-				r, c := t.lastRow, t.lastCol
-				t.mu.Unlock()
-				t.View.ScrollTo(r, c)
+				if follow {
+					t.View.ScrollToEnd()
+				} else {
+					t.mu.Lock()
+					r, c := t.lastRow, t.lastCol
+					t.mu.Unlock()
+					t.View.ScrollTo(r, c)
+				}
 			}
 			
 			// Notify parent to update title (e.g. counters changed)
@@ -118,6 +127,17 @@ func (t *TextViewer) refresh() {
 	}()
 }
 
+// atEnd reports whether the last line of the text is visible
+func (t *TextViewer) atEnd() bool {
+	text := t.View.GetText(false)
+	if text == "" {
+		return true
+	}
+	row, _ := t.View.GetScrollOffset()
+	_, _, _, height := t.View.GetInnerRect()
+	return row+height >= strings.Count(strings.TrimSuffix(text, "\n"), "\n")+1
+}
+
 // InputHandler handles common keys: n, p, c, /
 // Returns true if handled
 func (t *TextViewer) InputHandler(event *tcell.EventKey) bool {
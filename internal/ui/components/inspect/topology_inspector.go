@@ -0,0 +1,92 @@
+package inspect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jr-k/d4s/internal/dao"
+	"github.com/jr-k/d4s/internal/ui/styles"
+	"github.com/rivo/tview"
+)
+
+// NewTopologyInspector shows networks as groups of their attached containers,
+// filled by the caller with RenderTopology once loaded
+func NewTopologyInspector(subject string) *TextInspector {
+	return NewTextInspector("Topology", subject, fmt.Sprintf(" [%s]Loading topology...\n", styles.TagAccent), "text")
+}
+
+// RenderTopology draws each network with its containers (IP, aliases, ports).
+// Containers attached to several networks are highlighted: they are the
+// bridges between otherwise isolated groups.
+func RenderTopology(networks []dao.TopologyNetwork) string {
+	var sb strings.Builder
+	empty := 0
+	for _, n := range networks {
+		if len(n.Endpoints) == 0 {
+			empty++
+			continue
+		}
+
+		flags := n.Driver
+		if n.Subnet != "" {
+			flags += "  " + n.Subnet
+		}
+		if n.Internal {
+			flags += "  internal"
+		}
+		sb.WriteString(fmt.Sprintf(" [%s::b]%s[-::-]  [%s]%s[-]\n", styles.TagCyan, tview.Escape(n.Name), styles.TagDim, tview.Escape(flags)))
+
+		for i, ep := range n.Endpoints {
+			branch := "├─"
+			if i == len(n.Endpoints)-1 {
+				branch = "└─"
+			}
+
+			name := tview.Escape(ep.Name)
+			switch {
+			case ep.State != "running":
+				name = fmt.Sprintf("[%s]%s (%s)[-]", styles.TagDim, name, ep.State)
+			case ep.Networks > 1:
+				name = fmt.Sprintf("[%s::b]%s[-::-]", styles.TagAccent, name)
+			}
+
+			ip := ep.IPv4
+			if ep.IPv6 != "" {
+				ip = strings.TrimSpace(ip + " " + ep.IPv6)
+			}
+			if ip == "" {
+				ip = "-"
+			}
+
+			line := fmt.Sprintf(" [%s]%s[-] %s  %s", styles.TagDim, branch, name, ip)
+			if len(ep.Aliases) > 0 {
+				line += fmt.Sprintf("  [%s]aka %s[-]", styles.TagDim, tview.Escape(strings.Join(ep.Aliases, ", ")))
+			}
+			if ep.Networks > 1 {
+				line += fmt.Sprintf("  [%s]⇄ %d networks[-]", styles.TagAccent, ep.Networks)
+			}
+			if len(ep.Ports) > 0 {
+				line += fmt.Sprintf("  [%s]%s[-]", styles.TagInfo, tview.Escape(strings.Join(ep.Ports, ", ")))
+			}
+			if ep.Project != "" {
+				line += fmt.Sprintf("  [%s]📦 %s[-]", styles.TagDim, tview.Escape(ep.Project))
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if empty > 0 {
+		var names []string
+		for _, n := range networks {
+			if len(n.Endpoints) == 0 {
+				names = append(names, n.Name)
+			}
+		}
+		sb.WriteString(fmt.Sprintf(" [%s]No containers: %s[-]\n", styles.TagDim, tview.Escape(strings.Join(names, ", "))))
+	}
+	if sb.Len() == 0 {
+		sb.WriteString(fmt.Sprintf(" [%s]No networks[-]\n", styles.TagDim))
+	}
+	return sb.String()
+}
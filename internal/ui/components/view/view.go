@@ -67,6 +67,7 @@ type ResourceView struct {
 	ColumnWidths []int                  // Cache for column widths
 	CurrentScope *common.Scope          // Tracks which scope the current data belongs to
 	IsLoading    bool                   // Navigation lock
+	TitleInfo    string                 // Extra info shown next to the count (set by FetchFunc, e.g. a total size)
 
 	// Guard against overlapping background fetches (slow SSH transports)
 	fetchInFlight atomic.Bool
@@ -76,6 +77,9 @@ type ResourceView struct {
 	PinnedSortColumn string // Column name (e.g. "ANON"), resolved dynamically
 	PinnedSortAsc    bool
 
+	// Skip background preloading (expensive fetch, only run when displayed)
+	LazyLoad bool
+
 	// Optional Overrides
 	InputHandler             func(event *tcell.EventKey) *tcell.EventKey
 	ShortcutsFunc            func() []string
@@ -711,7 +715,7 @@ func (v *ResourceView) renderAll() {
 
 func isNumericColumn(name string) bool {
 	n := strings.ToUpper(name)
-	return n == "SIZE" || n == "REPLICAS" || n == "CPU" || n == "MEM" || n == "CONTAINERS"
+	return n == "SIZE" || n == "REPLICAS" || n == "CPU" || n == "MEM" || n == "CONTAINERS" || n == "RESTARTS" || n == "EXIT"
 }
 
 func (v *ResourceView) SetActionState(id, action string, color tcell.Color) {
@@ -826,7 +830,7 @@ func (v *ResourceView) updateRowStyle(rowIndex int, item dao.Resource) {
 		}
 
 		// Align Right for numeric columns
-		if headerName == "SIZE" || headerName == "REPLICAS" || headerName == "CPU" || headerName == "MEM" || headerName == "CONTAINERS" {
+		if isNumericColumn(headerName) {
 			cell.SetAlign(tview.AlignRight)
 		}
 	}
@@ -0,0 +1,39 @@
+package dialogs
+
+import (
+	"strings"
+
+	"github.com/jr-k/d4s/internal/dao"
+	"github.com/jr-k/d4s/internal/ui/common"
+)
+
+// ShowBuildForm edits the settings of an image build, then calls onSubmit
+func ShowBuildForm(app common.AppController, opts dao.BuildOptions, onSubmit func(opts dao.BuildOptions)) {
+	fields := []FormField{
+		{Name: "context", Label: "Context dir", Type: FieldTypeInput, Default: opts.ContextDir, Placeholder: "."},
+		{Name: "dockerfile", Label: "Dockerfile", Type: FieldTypeInput, Default: opts.Dockerfile, Placeholder: "Dockerfile (relative to context)"},
+		{Name: "tags", Label: "Tags", Type: FieldTypeInput, Default: strings.Join(opts.Tags, ", "), Placeholder: "myapp:dev, registry.local/myapp:1.0"},
+		{Name: "args", Label: "Build args", Type: FieldTypeInput, Default: strings.Join(opts.BuildArgs, ", "), Placeholder: "KEY=value, ..."},
+		{Name: "target", Label: "Target", Type: FieldTypeInput, Default: opts.Target, Placeholder: "last stage"},
+		{Name: "platform", Label: "Platform", Type: FieldTypeInput, Default: opts.Platform, Placeholder: "linux/amd64"},
+	}
+
+	ShowForm(app, "Build Image", fields, func(result FormResult) {
+		next := opts
+		next.ContextDir = strings.TrimSpace(result["context"])
+		if next.ContextDir == "" {
+			next.ContextDir = "."
+		}
+		next.Dockerfile = strings.TrimSpace(result["dockerfile"])
+		next.Tags = splitList(result["tags"])
+		next.BuildArgs = splitList(result["args"])
+		next.Target = strings.TrimSpace(result["target"])
+		next.Platform = strings.TrimSpace(result["platform"])
+
+		if _, err := next.Args(""); err != nil {
+			app.SetFlashError(err.Error())
+			return
+		}
+		onSubmit(next)
+	})
+}
@@ -0,0 +1,69 @@
+package dialogs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jr-k/d4s/internal/dao"
+	daoCommon "github.com/jr-k/d4s/internal/dao/common"
+	"github.com/jr-k/d4s/internal/ui/common"
+)
+
+// ShowCaptureForm asks for the settings of a packet capture of target:
+// interface, BPF filter, duration and where the pcap goes.
+func ShowCaptureForm(app common.AppController, target string, opts dao.CaptureOptions, onSubmit func(opts dao.CaptureOptions)) {
+	wireshark := dao.WiresharkPath() != ""
+	if opts.File == "" {
+		cwd, _ := os.Getwd()
+		opts.File = filepath.Join(daoCommon.ShortenPath(cwd), fmt.Sprintf("%s-%s.pcap", target, time.Now().Format("20060102-150405")))
+	}
+	duration := ""
+	if opts.Duration > 0 {
+		duration = opts.Duration.String()
+	}
+
+	fields := []FormField{
+		{Name: "filter", Label: "BPF filter", Type: FieldTypeInput, Default: opts.Filter, Placeholder: "tcp port 5432"},
+		{Name: "interface", Label: "Interface", Type: FieldTypeInput, Default: opts.Interface, Placeholder: "any"},
+		{Name: "duration", Label: "Duration", Type: FieldTypeInput, Default: duration, Placeholder: "until stopped, e.g. 30s, 5m"},
+		{Name: "file", Label: "Local pcap file", Type: FieldTypeInput, Default: opts.File, Placeholder: "empty with Wireshark only"},
+	}
+	if wireshark {
+		fields = append(fields, FormField{Name: "wireshark", Label: "Open in Wireshark", Type: FieldTypeCheckbox, Default: fmt.Sprintf("%t", opts.Wireshark)})
+	}
+
+	description := "tcpdump runs in a helper sharing the network of " + target + ", the capture is streamed through the Docker API."
+	if !wireshark {
+		description += " Wireshark was not found on this machine."
+	}
+
+	ShowFormWithNote(app, fmt.Sprintf("Capture: %s", target), description, fields, func(result FormResult) {
+		next := opts
+		next.Filter = strings.TrimSpace(result["filter"])
+		next.Interface = strings.TrimSpace(result["interface"])
+		next.File = daoCommon.ExpandPath(strings.TrimSpace(result["file"]))
+		next.Wireshark = result["wireshark"] == "true"
+
+		next.Duration = 0
+		if d := strings.TrimSpace(result["duration"]); d != "" {
+			parsed, err := time.ParseDuration(d)
+			if secs, serr := strconv.Atoi(d); serr == nil {
+				parsed, err = time.Duration(secs)*time.Second, nil
+			}
+			if err != nil || parsed < 0 {
+				app.SetFlashError(fmt.Sprintf("invalid duration %q", d))
+				return
+			}
+			next.Duration = parsed
+		}
+		if next.File == "" && !next.Wireshark {
+			app.SetFlashError("a pcap file or Wireshark is required")
+			return
+		}
+		onSubmit(next)
+	})
+}
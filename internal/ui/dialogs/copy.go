@@ -0,0 +1,59 @@
+package dialogs
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	daoCommon "github.com/jr-k/d4s/internal/dao/common"
+	"github.com/jr-k/d4s/internal/ui/common"
+)
+
+// ShowCopyForm asks for the direction and paths of a file transfer between
+// this machine and target (a container or volume), then calls onSubmit with
+// the expanded local path.
+func ShowCopyForm(app common.AppController, target string, onSubmit func(upload bool, src, dst string)) {
+	items := []PickerItem{
+		{Label: "Upload", Value: "upload", Description: "Local file or directory to " + target, Shortcut: 'u'},
+		{Label: "Download", Value: "download", Description: "From " + target + " to this machine", Shortcut: 'd'},
+	}
+
+	ShowPicker(app, fmt.Sprintf("Copy Files: %s", target), items, func(direction string) {
+		upload := direction == "upload"
+
+		cwd, _ := os.Getwd()
+		local := FormField{Name: "local", Label: "Local path", Type: FieldTypeInput, Placeholder: "~/file.txt"}
+		remote := FormField{Name: "remote", Label: "Path in " + target, Type: FieldTypeInput, Default: "/", Placeholder: "/"}
+		var fields []FormField
+		if upload {
+			fields = []FormField{local, remote}
+		} else {
+			remote.Default = ""
+			local.Default = daoCommon.ShortenPath(cwd)
+			fields = []FormField{remote, local}
+		}
+
+		description := "Copies like docker cp: into the destination when it is an existing directory, under that name otherwise."
+		if docker := app.GetDocker(); docker != nil && docker.IsSSHContext() {
+			description += fmt.Sprintf(" Local paths are on this machine, files go through the API of %s.", docker.GetSSHHost())
+		}
+
+		title := "Upload to " + target
+		if !upload {
+			title = "Download from " + target
+		}
+		ShowFormWithNote(app, title, description, fields, func(result FormResult) {
+			localPath := daoCommon.ExpandPath(strings.TrimSpace(result["local"]))
+			remotePath := strings.TrimSpace(result["remote"])
+			if localPath == "" || remotePath == "" {
+				app.SetFlashError("both paths are required")
+				return
+			}
+			if upload {
+				onSubmit(true, localPath, remotePath)
+			} else {
+				onSubmit(false, remotePath, localPath)
+			}
+		})
+	})
+}
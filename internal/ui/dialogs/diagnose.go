@@ -0,0 +1,38 @@
+package dialogs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jr-k/d4s/internal/dao"
+	"github.com/jr-k/d4s/internal/ui/common"
+)
+
+// ShowDiagnoseForm asks for the target of a connectivity check from source
+// (listing the peers sharing one of its networks) and its timeout.
+func ShowDiagnoseForm(app common.AppController, source, target string, peers []string, onSubmit func(target string, timeout time.Duration)) {
+	description := fmt.Sprintf("From %s to a container, service or host, with an optional port.", source)
+	if len(peers) > 0 {
+		description += "\nOn a shared network: " + strings.Join(peers, ", ")
+	}
+	fields := []FormField{
+		{Name: "target", Label: "Target", Type: FieldTypeInput, Default: target, Placeholder: "db:5432, example.com:443"},
+		{Name: "timeout", Label: "Timeout (s)", Type: FieldTypeInput, Default: "3"},
+	}
+
+	ShowFormWithNote(app, fmt.Sprintf("Diagnose: %s", source), description, fields, func(result FormResult) {
+		target := strings.TrimSpace(result["target"])
+		if _, _, err := dao.ParseDiagnoseTarget(target); err != nil {
+			app.SetFlashError(fmt.Sprintf("%v", err))
+			return
+		}
+		secs, err := strconv.Atoi(strings.TrimSpace(result["timeout"]))
+		if err != nil || secs < 1 || secs > 60 {
+			app.SetFlashError("timeout must be between 1 and 60 seconds")
+			return
+		}
+		onSubmit(target, time.Duration(secs)*time.Second)
+	})
+}
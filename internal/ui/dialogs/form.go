@@ -33,6 +33,12 @@ func ShowForm(app common.AppController, title string, fields []FormField, onSubm
 }
 
 func ShowFormWithDescription(app common.AppController, title, description string, fields []FormField, onSubmit func(result FormResult)) {
+	ShowFormWithNote(app, title, "", fields, onSubmit)
+}
+
+// ShowFormWithNote shows a form with a note wrapped above its fields
+// (a command preview, what the action is about to do...).
+func ShowFormWithNote(app common.AppController, title, note string, fields []FormField, onSubmit func(result FormResult)) {
 	if len(fields) == 0 {
 		return
 	}
@@ -79,6 +85,26 @@ func ShowFormWithDescription(app common.AppController, title, description string
 	// Add top spacing
 	formRows.AddItem(empty(), 1, 0, false)
 
+	// Note (wrapped above the fields)
+	if note != "" {
+		descLines := len(tview.WordWrap(note, dialogWidth-4))
+		desc := tview.NewTextView().
+			SetDynamicColors(true).
+			SetWordWrap(true).
+			SetText(fmt.Sprintf("[%s]%s[-]", styles.TagDim, tview.Escape(note)))
+		desc.SetBackgroundColor(styles.ColorBlack)
+
+		descRow := tview.NewFlex().
+			SetDirection(tview.FlexColumn).
+			AddItem(empty(), 1, 0, false).
+			AddItem(desc, 0, 1, false).
+			AddItem(empty(), 1, 0, false)
+
+		formRows.AddItem(descRow, descLines, 0, false)
+		formRows.AddItem(empty(), 1, 0, false)
+		dialogHeight += descLines + 1
+	}
+
 	for i, f := range fields {
 		// Label
 		label := tview.NewTextView().
@@ -0,0 +1,79 @@
+package dialogs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jr-k/d4s/internal/dao"
+	"github.com/jr-k/d4s/internal/ui/common"
+)
+
+// ShowNetworkForm edits a network spec (settings, then a preview of the
+// equivalent command) and calls onSubmit with the validated spec.
+func ShowNetworkForm(app common.AppController, spec dao.NetworkSpec, onSubmit func(spec dao.NetworkSpec)) {
+	fields := []FormField{
+		{Name: "name", Label: "Name", Type: FieldTypeInput, Default: spec.Name},
+		{Name: "driver", Label: "Driver", Type: FieldTypeInput, Default: spec.Driver, Placeholder: "bridge, overlay, macvlan, ipvlan"},
+		{Name: "subnet", Label: "Subnet", Type: FieldTypeInput, Default: spec.Subnet, Placeholder: "172.28.0.0/16"},
+		{Name: "gateway", Label: "Gateway", Type: FieldTypeInput, Default: spec.Gateway, Placeholder: "172.28.0.1"},
+		{Name: "iprange", Label: "IP range", Type: FieldTypeInput, Default: spec.IPRange, Placeholder: "172.28.5.0/24"},
+		{Name: "internal", Label: "Internal", Type: FieldTypeCheckbox, Default: fmt.Sprintf("%t", spec.Internal)},
+		{Name: "attachable", Label: "Attachable", Type: FieldTypeCheckbox, Default: fmt.Sprintf("%t", spec.Attachable)},
+		{Name: "ipv6", Label: "IPv6", Type: FieldTypeCheckbox, Default: fmt.Sprintf("%t", spec.IPv6)},
+		{Name: "labels", Label: "Labels", Type: FieldTypeInput, Default: strings.Join(spec.Labels, ", "), Placeholder: "key=value, ..."},
+		{Name: "options", Label: "Driver options", Type: FieldTypeInput, Default: strings.Join(spec.Options, ", "), Placeholder: "parent=eth0, ..."},
+	}
+
+	ShowForm(app, "Create Network", fields, func(result FormResult) {
+		next := spec
+		next.Name = strings.TrimSpace(result["name"])
+		next.Driver = strings.TrimSpace(result["driver"])
+		next.Subnet = strings.TrimSpace(result["subnet"])
+		next.Gateway = strings.TrimSpace(result["gateway"])
+		next.IPRange = strings.TrimSpace(result["iprange"])
+		next.Internal = result["internal"] == "true"
+		next.Attachable = result["attachable"] == "true"
+		next.IPv6 = result["ipv6"] == "true"
+		next.Labels = splitList(result["labels"])
+		next.Options = splitList(result["options"])
+
+		if _, err := next.CreateOptions(); err != nil {
+			app.SetFlashError(fmt.Sprintf("%v", err))
+			return
+		}
+
+		preview := []FormField{
+			{Name: "confirm", Label: "Create", Type: FieldTypeCheckbox, Default: "true"},
+		}
+		ShowFormWithNote(app, "Create Network", next.DockerCommand(), preview, func(result FormResult) {
+			if result["confirm"] == "true" {
+				onSubmit(next)
+			}
+		})
+	})
+}
+
+// ShowNetworkConnectForm asks for the endpoint settings of subject joining
+// network. Every field is optional.
+func ShowNetworkConnectForm(app common.AppController, network, subject string, onSubmit func(spec dao.NetworkConnectSpec)) {
+	fields := []FormField{
+		{Name: "aliases", Label: "Aliases", Type: FieldTypeInput, Placeholder: "api, api.internal"},
+		{Name: "ipv4", Label: "IPv4 address", Type: FieldTypeInput, Placeholder: "172.28.5.10"},
+		{Name: "ipv6", Label: "IPv6 address", Type: FieldTypeInput, Placeholder: "fd00::10"},
+		{Name: "links", Label: "Links", Type: FieldTypeInput, Placeholder: "container[:alias], ..."},
+	}
+
+	ShowForm(app, fmt.Sprintf("Connect %s to %s", subject, network), fields, func(result FormResult) {
+		spec := dao.NetworkConnectSpec{
+			Aliases: splitList(result["aliases"]),
+			IPv4:    strings.TrimSpace(result["ipv4"]),
+			IPv6:    strings.TrimSpace(result["ipv6"]),
+			Links:   splitList(result["links"]),
+		}
+		if _, err := spec.EndpointSettings(); err != nil {
+			app.SetFlashError(fmt.Sprintf("%v", err))
+			return
+		}
+		onSubmit(spec)
+	})
+}
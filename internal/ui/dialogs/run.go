@@ -0,0 +1,84 @@
+package dialogs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jr-k/d4s/internal/dao"
+	daoCommon "github.com/jr-k/d4s/internal/dao/common"
+	"github.com/jr-k/d4s/internal/ui/common"
+)
+
+// ShowRunForm walks through the container creation steps (settings, env, preview)
+// starting from spec, then calls onSubmit with the edited spec.
+func ShowRunForm(app common.AppController, title string, spec dao.RunSpec, onSubmit func(spec dao.RunSpec, start bool)) {
+	fields := []FormField{
+		{Name: "name", Label: "Name", Type: FieldTypeInput, Default: spec.Name, Placeholder: "random"},
+		{Name: "image", Label: "Image", Type: FieldTypeInput, Default: spec.Image, Placeholder: "nginx:latest"},
+		{Name: "cmd", Label: "Command", Type: FieldTypeInput, Default: daoCommon.JoinCommandLine(spec.Cmd), Placeholder: "image default"},
+		{Name: "ports", Label: "Ports", Type: FieldTypeInput, Default: strings.Join(spec.Ports, ", "), Placeholder: "8080:80, 443:443/tcp"},
+		{Name: "volumes", Label: "Volumes", Type: FieldTypeInput, Default: strings.Join(spec.Volumes, ", "), Placeholder: "data:/data, /host:/mnt:ro"},
+		{Name: "networks", Label: "Networks", Type: FieldTypeInput, Default: strings.Join(spec.Networks, ", "), Placeholder: "bridge"},
+		{Name: "restart", Label: "Restart", Type: FieldTypeInput, Default: spec.RestartPolicy, Placeholder: "no|always|unless-stopped|on-failure:3"},
+		{Name: "labels", Label: "Labels", Type: FieldTypeInput, Default: strings.Join(spec.Labels, ", "), Placeholder: "key=value, ..."},
+		{Name: "cpus", Label: "CPUs", Type: FieldTypeInput, Default: spec.CPUs, Placeholder: "1.5"},
+		{Name: "memory", Label: "Memory", Type: FieldTypeInput, Default: spec.Memory, Placeholder: "512m"},
+		{Name: "rm", Label: "Auto remove", Type: FieldTypeCheckbox, Default: fmt.Sprintf("%t", spec.AutoRemove)},
+	}
+
+	ShowForm(app, title, fields, func(result FormResult) {
+		cmd, err := daoCommon.SplitCommandLine(result["cmd"])
+		if err != nil {
+			app.SetFlashError(fmt.Sprintf("%v", err))
+			return
+		}
+
+		next := spec
+		next.Name = strings.TrimSpace(result["name"])
+		next.Image = strings.TrimSpace(result["image"])
+		next.Cmd = cmd
+		next.Ports = splitList(result["ports"])
+		next.Volumes = splitList(result["volumes"])
+		next.Networks = splitList(result["networks"])
+		next.RestartPolicy = strings.TrimSpace(result["restart"])
+		next.Labels = splitList(result["labels"])
+		next.CPUs = strings.TrimSpace(result["cpus"])
+		next.Memory = strings.TrimSpace(result["memory"])
+		next.AutoRemove = result["rm"] == "true"
+
+		if _, _, _, err := next.Configs(); err != nil {
+			app.SetFlashError(fmt.Sprintf("%v", err))
+			return
+		}
+
+		// Step 2: environment
+		var items []EnvItem
+		for _, e := range next.Env {
+			k, v, _ := strings.Cut(e, "=")
+			items = append(items, EnvItem{Key: k, Value: v, Selected: true})
+		}
+
+		ShowEnvEditor(app, next.Image, items, func(envVars []string) {
+			next.Env = envVars
+
+			// Step 3: preview
+			preview := []FormField{
+				{Name: "start", Label: "Start", Type: FieldTypeCheckbox, Default: "true"},
+			}
+			ShowFormWithNote(app, title, next.DockerRunCommand(), preview, func(result FormResult) {
+				onSubmit(next, result["start"] == "true")
+			})
+		})
+	})
+}
+
+// splitList splits a comma separated input, dropping empty entries
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
@@ -0,0 +1,47 @@
+package dialogs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jr-k/d4s/internal/dao"
+	"github.com/jr-k/d4s/internal/ui/common"
+)
+
+// ShowVolumeForm edits a volume spec (settings, then a preview of the
+// equivalent command) and calls onSubmit with the validated spec.
+func ShowVolumeForm(app common.AppController, spec dao.VolumeSpec, onSubmit func(spec dao.VolumeSpec, testMount bool)) {
+	fields := []FormField{
+		{Name: "name", Label: "Name", Type: FieldTypeInput, Default: spec.Name, Placeholder: "random"},
+		{Name: "driver", Label: "Driver", Type: FieldTypeInput, Default: spec.Driver, Placeholder: "local"},
+		{Name: "options", Label: "Driver options", Type: FieldTypeTextArea, Default: strings.Join(spec.Options, "\n"), Placeholder: "one key=value per line\ntype=nfs\no=addr=10.0.0.1,rw,nfsvers=4\ndevice=:/export"},
+		{Name: "labels", Label: "Labels", Type: FieldTypeInput, Default: strings.Join(spec.Labels, ", "), Placeholder: "key=value, ..."},
+	}
+
+	ShowForm(app, "Create Volume", fields, func(result FormResult) {
+		next := spec
+		next.Name = strings.TrimSpace(result["name"])
+		next.Driver = strings.TrimSpace(result["driver"])
+		// Options go one per line: mount options (o=) contain commas
+		next.Options = nil
+		for _, line := range strings.Split(result["options"], "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				next.Options = append(next.Options, line)
+			}
+		}
+		next.Labels = splitList(result["labels"])
+
+		if _, err := next.CreateOptions(); err != nil {
+			app.SetFlashError(fmt.Sprintf("%v", err))
+			return
+		}
+
+		// Preview, remote filesystems only fail on their first mount
+		preview := []FormField{
+			{Name: "test", Label: "Test mount", Type: FieldTypeCheckbox, Default: fmt.Sprintf("%t", len(next.Options) > 0)},
+		}
+		ShowFormWithNote(app, "Create Volume", next.DockerCommand(), preview, func(result FormResult) {
+			onSubmit(next, result["test"] == "true")
+		})
+	})
+}
@@ -141,6 +141,9 @@ const (
 	TitleContexts     = "Contexts"
 	TitlePlugins      = "Plugins"
 	TitlePortForwards = "PortForwards"
+	TitleDiskUsage    = "DiskUsage"
+	TitleBuildCache   = "BuildCache"
+	TitleRemoteTags   = "RemoteTags"
 )
 
 // invertColor inverts a tcell.Color by flipping its lightness while preserving hue and saturation.
@@ -73,6 +73,8 @@ func Fetch(app common.AppController, v *view.ResourceView) ([]dao.Resource, erro
 		{Title: styles.TitlePlugins, Resource: "plugins", Group: "docker", Shortcuts: []string{"g", "pl", "plugin", "plugins"}},
 		{Title: styles.TitleCompose, Resource: "compose", Group: "compose", Shortcuts: []string{"p", "cp", "compose", "project", "projects"}},
 		{Title: styles.TitlePortForwards, Resource: "portforwards", Group: "internal", Shortcuts: []string{"w", "pf", "portforward", "portforwards"}},
+		{Title: styles.TitleDiskUsage, Resource: "diskusage", Group: "docker", Shortcuts: []string{"du", "df", "diskusage"}},
+		{Title: styles.TitleBuildCache, Resource: "buildcache", Group: "docker", Shortcuts: []string{"bc", "buildcache", "builder"}},
 	}
 
 	var resources []dao.Resource
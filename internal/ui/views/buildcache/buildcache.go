@@ -0,0 +1,169 @@
+package buildcache
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jr-k/d4s/internal/dao"
+	daoCommon "github.com/jr-k/d4s/internal/dao/common"
+	"github.com/jr-k/d4s/internal/ui/common"
+	"github.com/jr-k/d4s/internal/ui/components/inspect"
+	"github.com/jr-k/d4s/internal/ui/components/view"
+	"github.com/jr-k/d4s/internal/ui/dialogs"
+	"github.com/jr-k/d4s/internal/ui/styles"
+)
+
+var Headers = []string{"ID", "TYPE", "SIZE", "LAST USED", "SHARED", "IN USE", "DESCRIPTION"}
+
+func Fetch(app common.AppController, v *view.ResourceView) ([]dao.Resource, error) {
+	records, err := app.GetDocker().ListBuildCache()
+	if err != nil {
+		return nil, err
+	}
+
+	var total, reclaimable int64
+	for _, r := range records {
+		if b, ok := r.(dao.BuildCache); ok {
+			total += b.Size
+			if !b.InUse && !b.Shared {
+				reclaimable += b.Size
+			}
+		}
+	}
+	v.TitleInfo = fmt.Sprintf("%s, %s reclaimable", daoCommon.FormatBytes(total), daoCommon.FormatBytes(reclaimable))
+
+	return records, nil
+}
+
+func GetShortcuts() []string {
+	return []string{
+		common.FormatSCHeader("d", "Describe"),
+		common.FormatSCHeader("shift-p", "Prune"),
+		common.FormatSCHeader("ctrl-d", "Delete"),
+	}
+}
+
+func InputHandler(v *view.ResourceView, event *tcell.EventKey) *tcell.EventKey {
+	app := v.App
+
+	if event.Key() == tcell.KeyCtrlD {
+		DeleteAction(app, v)
+		return nil
+	}
+
+	switch event.Rune() {
+	case 'd':
+		app.InspectCurrentSelection()
+		return nil
+	case 'P':
+		PruneAction(app)
+		return nil
+	}
+
+	return event
+}
+
+func Inspect(app common.AppController, id string) {
+	inspector := inspect.NewTextInspector("Describe build cache", id[:min(12, len(id))], fmt.Sprintf(" [%s]Loading build cache...\n", styles.TagAccent), "yaml")
+	app.OpenInspector(inspector)
+
+	app.RunInBackground(func() {
+		records, err := app.GetDocker().ListBuildCache()
+		content := fmt.Sprintf("Error: record %s not found", id)
+		if err != nil {
+			content = fmt.Sprintf("Error: %v", err)
+		}
+		for _, r := range records {
+			if b, ok := r.(dao.BuildCache); ok && b.ID == id {
+				content = formatRecord(b)
+				break
+			}
+		}
+		app.GetTviewApp().QueueUpdateDraw(func() {
+			inspector.Viewer.Update(content, "yaml")
+		})
+	})
+}
+
+func formatRecord(b dao.BuildCache) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("id: %s\n", b.ID))
+	sb.WriteString(fmt.Sprintf("type: %s\n", b.Type))
+	sb.WriteString(fmt.Sprintf("description: %q\n", b.Description))
+	sb.WriteString(fmt.Sprintf("size: %s\n", daoCommon.FormatBytes(b.Size)))
+	sb.WriteString(fmt.Sprintf("createdAt: %s\n", b.CreatedAt.Local().Format("2006-01-02 15:04:05")))
+	if b.LastUsedAt != nil {
+		sb.WriteString(fmt.Sprintf("lastUsedAt: %s\n", b.LastUsedAt.Local().Format("2006-01-02 15:04:05")))
+	}
+	sb.WriteString(fmt.Sprintf("usageCount: %d\n", b.UsageCount))
+	sb.WriteString(fmt.Sprintf("shared: %t\n", b.Shared))
+	sb.WriteString(fmt.Sprintf("inUse: %t\n", b.InUse))
+	if len(b.Parents) == 0 {
+		sb.WriteString("parents: []\n")
+	} else {
+		sb.WriteString("parents:\n")
+		for _, p := range b.Parents {
+			sb.WriteString(fmt.Sprintf("  - %s\n", p))
+		}
+	}
+	return sb.String()
+}
+
+func Remove(id string, force bool, app common.AppController) error {
+	return app.GetDocker().RemoveBuildCache(id)
+}
+
+func DeleteAction(app common.AppController, v *view.ResourceView) {
+	ids, err := v.GetSelectedIDs()
+	if err != nil {
+		return
+	}
+
+	label := ids[0][:min(12, len(ids[0]))]
+	if len(ids) > 1 {
+		label = fmt.Sprintf("%d items", len(ids))
+	}
+
+	dialogs.ShowConfirmation(app, "DELETE", label, func(force bool) {
+		simpleAction := func(id string) error {
+			return Remove(id, force, app)
+		}
+		app.PerformAction(simpleAction, "deleting", styles.ColorStatusRed)
+	})
+}
+
+// PruneAction removes the build cache matching an age and/or size filter
+func PruneAction(app common.AppController) {
+	if app.IsReadOnly() {
+		app.AppendFlashError("read-only mode: modifications are disabled")
+		return
+	}
+
+	fields := []dialogs.FormField{
+		{Name: "until", Label: "Unused for", Type: dialogs.FieldTypeInput, Placeholder: "24h, 7d (empty: any age)"},
+		{Name: "keep", Label: "Keep storage", Type: dialogs.FieldTypeInput, Placeholder: "10g (empty: keep nothing)"},
+		{Name: "all", Label: "Include shared", Type: dialogs.FieldTypeCheckbox, Default: "false"},
+	}
+
+	dialogs.ShowFormWithNote(app, "Prune Build Cache", "Records in use are never removed", fields, func(result dialogs.FormResult) {
+		opts := dao.BuildCachePruneOptions{
+			OlderThan:   result["until"],
+			KeepStorage: result["keep"],
+			All:         result["all"] == "true",
+		}
+
+		app.SetFlashPending("pruning build cache...")
+		app.RunInBackground(func() {
+			reclaimed, err := app.GetDocker().PruneBuildCache(opts)
+			app.GetTviewApp().QueueUpdateDraw(func() {
+				if err != nil {
+					app.SetFlashError(fmt.Sprintf("%v", err))
+					return
+				}
+				app.SetFlashSuccess(fmt.Sprintf("pruned build cache, %s reclaimed", daoCommon.FormatBytes(reclaimed)))
+				app.RefreshCurrentView()
+			})
+		})
+	})
+}
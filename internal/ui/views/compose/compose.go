@@ -21,12 +21,29 @@ import (
 
 var Headers = []string{"PROJECT", "READY", "STATUS", "CONFIG FILES"}
 
+// UpdateHeaders are the columns when image update checks are enabled
+var UpdateHeaders = []string{"PROJECT", "READY", "STATUS", "UPDATE", "CONFIG FILES"}
+
 func Fetch(app common.AppController, v *view.ResourceView) ([]dao.Resource, error) {
 	data, err := app.GetDocker().ListCompose()
 	if err != nil {
 		return nil, err
 	}
 
+	v.Headers = Headers
+	if cfg := app.GetConfig().D4S.ImageUpdates; cfg.Enabled {
+		v.Headers = UpdateHeaders
+		// Best effort, registry checks run in the background
+		updates, _ := app.GetDocker().ComposeUpdates(cfg.GetInterval())
+		for i, res := range data {
+			if cp, ok := res.(daoCompose.ComposeProject); ok {
+				cp.ShowUpdates = true
+				cp.Updates = updates[cp.Name]
+				data[i] = cp
+			}
+		}
+	}
+
 	scope := app.GetActiveScope()
 	if scope != nil {
 		if scope.Type == "container" {
@@ -89,6 +106,7 @@ func GetShortcuts() []string {
 		common.FormatSCHeader("b", "Build"),
 		common.FormatSCHeader("shift-f", "Port-Forward"),
 		common.FormatSCHeader("shift-r", "(Re)Deploy"),
+		common.FormatSCHeader("shift-u", "Pull & Update"),
 		common.FormatSCHeader("ctrl-d", "Delete"),
 		common.FormatSCHeader("ctrl-k", "Stop"),
 	}
@@ -129,6 +147,9 @@ func InputHandler(v *view.ResourceView, event *tcell.EventKey) *tcell.EventKey {
 	case 'b':
 		BuildAction(app, v)
 		return nil
+	case 'U':
+		UpdateAction(app, v)
+		return nil
 	}
 	
 	if event.Key() == tcell.KeyEnter {
@@ -261,6 +282,13 @@ func RedeployAction(app common.AppController, v *view.ResourceView) {
 	}, "redeploying", styles.ColorStatusMagenta)
 }
 
+// UpdateAction pulls the project images and recreates the outdated containers
+func UpdateAction(app common.AppController, v *view.ResourceView) {
+	app.PerformAction(func(id string) error {
+		return app.GetDocker().UpdateComposeProject(id)
+	}, "updating", styles.ColorStatusOrange)
+}
+
 func BuildAction(app common.AppController, v *view.ResourceView) {
 	app.PerformAction(func(id string) error {
 		return app.GetDocker().BuildComposeProject(id)
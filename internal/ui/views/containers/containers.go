@@ -5,40 +5,67 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/docker/docker/api/types/container"
 	"github.com/gdamore/tcell/v2"
 	"github.com/jr-k/d4s/internal/dao"
+	daoCommon "github.com/jr-k/d4s/internal/dao/common"
 	"github.com/jr-k/d4s/internal/portforward"
 	"github.com/jr-k/d4s/internal/ui/common"
 	"github.com/jr-k/d4s/internal/ui/components/inspect"
 	"github.com/jr-k/d4s/internal/ui/components/view"
 	"github.com/jr-k/d4s/internal/ui/dialogs"
 	"github.com/jr-k/d4s/internal/ui/styles"
+	"github.com/rivo/tview"
 )
 
 var Headers = []string{"ID", "NAME", "IMAGE", "STATUS", "CPU", "MEM", "AGE", "PF", "IP", "PORTS", "COMPOSE", "CMD", "CREATED"}
 
+// UpdateHeader is the column added after PF when image update checks are enabled
+const UpdateHeader = "UPDATE"
+
+// StateHeaders are the optional columns toggled with 'w'
+var StateHeaders = []string{"RESTARTS", "EXIT", "OOM", "HEALTH"}
+
+// showState toggles the optional state columns
+var showState bool
+
 type containerWithPF struct {
 	dao.Container
-	pf string
+	pf      string
+	update  string
+	updates bool // UPDATE column shown
+	state   bool
 }
 
 func (c containerWithPF) GetCells() []string {
 	cells := c.Container.GetCells()
-	// Insert PF at index 7 (before IP)
-	result := make([]string, 0, len(cells)+1)
+	// Insert PF (and UPDATE) at index 7 (before IP)
+	result := make([]string, 0, len(cells)+2+len(StateHeaders))
 	result = append(result, cells[:7]...)
 	result = append(result, c.pf)
+	if c.updates {
+		result = append(result, c.update)
+	}
 	result = append(result, cells[7:]...)
+	if c.state {
+		result = append(result, c.Container.GetStateCells()...)
+	}
 	return result
 }
 
 func (c containerWithPF) GetColumnValue(column string) string {
-	if strings.ToLower(column) == "pf" {
+	switch strings.ToLower(column) {
+	case "pf":
 		return c.pf
+	case "update":
+		return c.update
 	}
 	return c.Container.GetColumnValue(column)
 }
@@ -54,6 +81,15 @@ func asContainer(res dao.Resource) (dao.Container, bool) {
 }
 
 func Fetch(app common.AppController, v *view.ResourceView) ([]dao.Resource, error) {
+	headers := Headers
+	if app.GetConfig().D4S.ImageUpdates.Enabled {
+		headers = slices.Insert(slices.Clone(Headers), 8, UpdateHeader)
+	}
+	if showState {
+		headers = append(slices.Clone(headers), StateHeaders...)
+	}
+	v.Headers = headers
+
 	data, err := app.GetDocker().ListContainers()
 	if err != nil {
 		return nil, err
@@ -142,13 +178,19 @@ func Fetch(app common.AppController, v *view.ResourceView) ([]dao.Resource, erro
 
 func wrapWithPF(data []dao.Resource, app common.AppController) []dao.Resource {
 	pfMgr := app.GetPortForwardManager()
+	updates := app.GetConfig().D4S.ImageUpdates
 	for i, res := range data {
 		if c, ok := res.(dao.Container); ok {
 			pf := ""
 			if pfMgr.GetForContainer(c.ID) != nil {
 				pf = "●"
 			}
-			data[i] = containerWithPF{Container: c, pf: pf}
+			// Registry digests are checked in the background, the marker shows up on a later refresh
+			update := ""
+			if updates.Enabled && c.State == "running" && app.GetDocker().ImageUpdateAvailable(c.Image, c.ImageID, updates.GetInterval()) {
+				update = "⬆"
+			}
+			data[i] = containerWithPF{Container: c, pf: pf, update: update, updates: updates.Enabled, state: showState}
 		}
 	}
 	return data
@@ -162,16 +204,28 @@ func GetShortcuts() []string {
 		common.FormatSCHeader("i", "Image"),
 		common.FormatSCHeader("d", "Describe"),
 		common.FormatSCHeader("e", "Env"),
+		common.FormatSCHeader("b", "Browse Files"),
+		common.FormatSCHeader("y", "Copy Files"),
 		common.FormatSCHeader("t", "Stats"),
 		common.FormatSCHeader("m", "Monitor"),
 		common.FormatSCHeader("v", "Volumes"),
 		common.FormatSCHeader("n", "Networks"),
 		common.FormatSCHeader("p", "Project"),
 		common.FormatSCHeader("r", "(Re)Start"),
+		common.FormatSCHeader("z", "(Un)Pause"),
+		common.FormatSCHeader("h", "Health"),
+		common.FormatSCHeader("w", "State Columns"),
 		common.FormatSCHeader("shift-f", "Port-Forward"),
 		common.FormatSCHeader("shift-p", "Prune"),
 		common.FormatSCHeader("shift-s", "Root Shell"),
 		common.FormatSCHeader("shift-n", "Attach Network"),
+		common.FormatSCHeader("shift-d", "Diagnose"),
+		common.FormatSCHeader("shift-t", "Capture"),
+		common.FormatSCHeader("shift-e", "Recreate/Clone"),
+		common.FormatSCHeader("shift-u", "Pull & Recreate"),
+		common.FormatSCHeader("shift-l", "Limits"),
+		common.FormatSCHeader("shift-k", "Kill"),
+		common.FormatSCHeader("shift-r", "Rename"),
 		common.FormatSCHeader("ctrl-k", "Stop"),
 		common.FormatSCHeader("ctrl-d", "Delete"),
 	}
@@ -203,6 +257,12 @@ func InputHandler(v *view.ResourceView, event *tcell.EventKey) *tcell.EventKey {
 	case 'e':
 		Env(app, v)
 		return nil
+	case 'b':
+		BrowseFiles(app, v)
+		return nil
+	case 'y':
+		CopyAction(app, v)
+		return nil
 	case 't':
 		Stats(app, v)
 		return nil
@@ -218,6 +278,21 @@ func InputHandler(v *view.ResourceView, event *tcell.EventKey) *tcell.EventKey {
 	case 'N':
 		NetworksPicker(app, v)
 		return nil
+	case 'D':
+		DiagnoseAction(app, v)
+		return nil
+	case 'T':
+		CaptureAction(app, v)
+		return nil
+	case 'E':
+		RecreateAction(app, v)
+		return nil
+	case 'U':
+		PullRecreateAction(app, v)
+		return nil
+	case 'L':
+		LimitsAction(app, v)
+		return nil
 	case 'l':
 		Logs(app, v)
 		return nil
@@ -244,9 +319,24 @@ func InputHandler(v *view.ResourceView, event *tcell.EventKey) *tcell.EventKey {
 	case 'd':
 		Describe(app, v)
 		return nil
+	case 'h':
+		Health(app, v)
+		return nil
+	case 'w':
+		ToggleStateColumns(app)
+		return nil
 	case 'r':
 		RestartOrStart(app, v)
 		return nil
+	case 'z':
+		PauseOrUnpause(app, v)
+		return nil
+	case 'K':
+		KillAction(app, v)
+		return nil
+	case 'R':
+		RenameAction(app, v)
+		return nil
 	case 'P':
 		PruneAction(app)
 		return nil
@@ -304,6 +394,168 @@ func Env(app common.AppController, v *view.ResourceView) {
 	app.OpenInspector(inspect.NewTextInspector("Environment container", subject, strings.Join(lines, "\n"), "env"))
 }
 
+// BrowseFiles opens the file browser on the container filesystem
+func BrowseFiles(app common.AppController, v *view.ResourceView) {
+	id, err := v.GetSelectedID()
+	if err != nil { return }
+
+	subject := strings.SplitN(resolveContainerSubject(v, id), "@", 2)[0]
+	app.OpenInspector(inspect.NewFileBrowser("Files container", subject, id, "/"))
+}
+
+// CopyAction uploads or downloads files between this machine and the container
+func CopyAction(app common.AppController, v *view.ResourceView) {
+	id, err := v.GetSelectedID()
+	if err != nil { return }
+
+	subject := strings.SplitN(resolveContainerSubject(v, id), "@", 2)[0]
+	dialogs.ShowCopyForm(app, subject, func(upload bool, src, dst string) {
+		if upload && app.IsReadOnly() {
+			app.AppendFlashError("read-only mode: modifications are disabled")
+			return
+		}
+
+		action := "Download container"
+		if upload {
+			action = "Upload container"
+		}
+		progress := dao.NewCopyProgress(src, dst)
+		app.RunInBackground(func() {
+			var err error
+			if upload {
+				err = app.GetDocker().UploadToContainer(id, src, dst, progress)
+			} else {
+				err = app.GetDocker().DownloadFromContainer(id, src, dst, progress)
+			}
+			app.GetTviewApp().QueueUpdateDraw(func() {
+				if err != nil {
+					app.SetFlashError(fmt.Sprintf("%v", err))
+				} else {
+					app.SetFlashSuccess(fmt.Sprintf("copied %s to %s", src, dst))
+				}
+			})
+		})
+		app.OpenInspector(inspect.NewCopyInspector(action, subject, progress))
+	})
+}
+
+// Last diagnose target, offered again for the next check
+var lastDiagnoseTarget string
+
+// DiagnoseAction checks DNS resolution and TCP reachability of a target from
+// the network namespace of the selected container
+func DiagnoseAction(app common.AppController, v *view.ResourceView) {
+	if app.IsReadOnly() {
+		app.AppendFlashError("read-only mode: modifications are disabled")
+		return
+	}
+
+	id, err := v.GetSelectedID()
+	if err != nil { return }
+
+	subject := strings.SplitN(resolveContainerSubject(v, id), "@", 2)[0]
+
+	// Suggest the listed containers sharing a network with the source
+	var source dao.Container
+	for _, item := range v.Data {
+		if c, ok := item.(dao.Container); ok && c.ID == id {
+			source = c
+		}
+	}
+	var peers []string
+	for _, item := range v.Data {
+		c, ok := item.(dao.Container)
+		if !ok || c.ID == id {
+			continue
+		}
+		for name := range c.Networks {
+			if _, shared := source.Networks[name]; shared {
+				peers = append(peers, c.Names)
+				break
+			}
+		}
+	}
+	sort.Strings(peers)
+
+	dialogs.ShowDiagnoseForm(app, subject, lastDiagnoseTarget, peers, func(target string, timeout time.Duration) {
+		lastDiagnoseTarget = target
+		inspector := inspect.NewDiagnoseInspector(subject, target)
+		app.OpenInspector(inspector)
+
+		app.RunInBackground(func() {
+			result, err := app.GetDocker().DiagnoseContainer(id, app.GetConfig().D4S.NetTools.Image, target, timeout)
+			app.GetTviewApp().QueueUpdateDraw(func() {
+				if err != nil {
+					inspector.Viewer.Update(fmt.Sprintf("Error: %v", err), "text")
+					return
+				}
+				inspector.Viewer.Update(inspect.RenderDiagnosis(subject, result), "text")
+			})
+		})
+	})
+}
+
+// Last capture settings, offered again for the next capture (with a new file)
+var lastCapture = dao.CaptureOptions{Duration: time.Minute}
+
+// CaptureAction records the traffic of the selected container with tcpdump,
+// to a local pcap file and/or a local Wireshark
+func CaptureAction(app common.AppController, v *view.ResourceView) {
+	if app.IsReadOnly() {
+		app.AppendFlashError("read-only mode: modifications are disabled")
+		return
+	}
+
+	id, err := v.GetSelectedID()
+	if err != nil { return }
+
+	subject := strings.SplitN(resolveContainerSubject(v, id), "@", 2)[0]
+	defaults := lastCapture
+	defaults.File = ""
+	defaults.Overwrite = false
+	dialogs.ShowCaptureForm(app, subject, defaults, func(opts dao.CaptureOptions) {
+		lastCapture = opts
+		capture := func() {
+			progress := dao.NewCopyProgress(subject, opts.File)
+			stop := make(chan struct{})
+			var once sync.Once
+
+			app.RunInBackground(func() {
+				err := app.GetDocker().CaptureContainer(id, app.GetConfig().D4S.NetTools.Image, opts, progress, stop)
+				app.GetTviewApp().QueueUpdateDraw(func() {
+					if err != nil {
+						app.SetFlashError(fmt.Sprintf("%v", err))
+					} else if opts.File != "" {
+						app.SetFlashSuccess(fmt.Sprintf("capture saved to %s", opts.File))
+					} else {
+						app.SetFlashSuccess("capture ended")
+					}
+				})
+			})
+			app.OpenInspector(inspect.NewCaptureInspector(subject, opts, progress, func() {
+				once.Do(func() { close(stop) })
+			}))
+		}
+
+		if opts.File == "" {
+			capture()
+			return
+		}
+		info, err := os.Stat(opts.File)
+		switch {
+		case err != nil:
+			capture()
+		case info.IsDir():
+			app.SetFlashError(fmt.Sprintf("%s is a directory", opts.File))
+		default:
+			dialogs.ShowConfirmation(app, "OVERWRITE", daoCommon.ShortenPath(opts.File), func(force bool) {
+				opts.Overwrite = true
+				capture()
+			})
+		}
+	})
+}
+
 func Stats(app common.AppController, v *view.ResourceView) {
 	id, err := v.GetSelectedID()
 	if err != nil { return }
@@ -385,7 +637,7 @@ func Describe(app common.AppController, v *view.ResourceView) {
 	id, err := v.GetSelectedID()
 	if err != nil { return }
 
-	content, err := app.GetDocker().Inspect("container", id)
+	content, endpoints, err := app.GetDocker().DescribeContainer(id)
 	if err != nil {
 		app.SetFlashError(fmt.Sprintf("%v", err))
 		return
@@ -410,7 +662,122 @@ func Describe(app common.AppController, v *view.ResourceView) {
 		subject = fmt.Sprintf("%s@%s", name, subject)
 	}
 
-	app.OpenInspector(inspect.NewTextInspector("Describe container", subject, content, "json"))
+	// Networks summary above the JSON (aliases and IPs are deep in NetworkSettings)
+	inspector := inspect.NewTextInspector("Describe container", subject, content, "json")
+	if len(endpoints) > 0 {
+		inspector.HeaderTitle = "Networks"
+		inspector.Header = formatEndpoints(endpoints)
+	}
+	app.OpenInspector(inspector)
+}
+
+func formatEndpoints(endpoints []dao.NetworkEndpoint) string {
+	var sb strings.Builder
+	for _, ep := range endpoints {
+		ips := strings.TrimSpace(ep.IPv4 + " " + ep.IPv6)
+		if ips == "" {
+			ips = "-"
+		}
+		if ep.Static {
+			ips += " (static)"
+		}
+		line := fmt.Sprintf(" [%s]%s[-]  %s", styles.TagAccent, tview.Escape(ep.Network), tview.Escape(ips))
+		if len(ep.Aliases) > 0 {
+			line += "  aliases: " + tview.Escape(strings.Join(ep.Aliases, ", "))
+		}
+		if len(ep.Links) > 0 {
+			line += "  links: " + tview.Escape(strings.Join(ep.Links, ", "))
+		}
+		sb.WriteString(line + "\n")
+	}
+	return sb.String()
+}
+
+// Health shows the healthcheck configuration and the last probe outputs
+func Health(app common.AppController, v *view.ResourceView) {
+	id, err := v.GetSelectedID()
+	if err != nil { return }
+
+	cfg, health, err := app.GetDocker().GetContainerHealth(id)
+	if err != nil {
+		app.SetFlashError(fmt.Sprintf("%v", err))
+		return
+	}
+	if cfg == nil && health == nil {
+		app.SetFlashError("no healthcheck configured for this container")
+		return
+	}
+
+	subject := resolveContainerSubject(v, id)
+	app.OpenInspector(inspect.NewTextInspector("Health container", subject, formatHealth(cfg, health), "yaml"))
+}
+
+func formatHealth(cfg *container.HealthConfig, health *container.Health) string {
+	var sb strings.Builder
+
+	if health != nil {
+		sb.WriteString(fmt.Sprintf("status: %s\n", health.Status))
+		sb.WriteString(fmt.Sprintf("failingStreak: %d\n", health.FailingStreak))
+	}
+
+	if cfg != nil {
+		sb.WriteString("healthcheck:\n")
+		if len(cfg.Test) > 0 {
+			sb.WriteString(fmt.Sprintf("  test: %q\n", strings.Join(cfg.Test, " ")))
+		}
+		if cfg.Interval > 0 {
+			sb.WriteString(fmt.Sprintf("  interval: %s\n", cfg.Interval))
+		}
+		if cfg.Timeout > 0 {
+			sb.WriteString(fmt.Sprintf("  timeout: %s\n", cfg.Timeout))
+		}
+		if cfg.StartPeriod > 0 {
+			sb.WriteString(fmt.Sprintf("  startPeriod: %s\n", cfg.StartPeriod))
+		}
+		if cfg.Retries > 0 {
+			sb.WriteString(fmt.Sprintf("  retries: %d\n", cfg.Retries))
+		}
+	}
+
+	if health == nil || len(health.Log) == 0 {
+		sb.WriteString("probes: []\n")
+		return sb.String()
+	}
+
+	// Most recent probe first
+	sb.WriteString("probes:\n")
+	for i := len(health.Log) - 1; i >= 0; i-- {
+		probe := health.Log[i]
+		if probe == nil {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("  - start: %s\n", probe.Start.Local().Format("2006-01-02 15:04:05")))
+		sb.WriteString(fmt.Sprintf("    duration: %s\n", probe.End.Sub(probe.Start).Round(time.Millisecond)))
+		sb.WriteString(fmt.Sprintf("    exitCode: %d\n", probe.ExitCode))
+		output := strings.TrimRight(probe.Output, "\n")
+		if output == "" {
+			sb.WriteString("    output: \"\"\n")
+			continue
+		}
+		sb.WriteString("    output: |\n")
+		for _, line := range strings.Split(output, "\n") {
+			sb.WriteString("      " + line + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// ToggleStateColumns shows/hides the RESTARTS, EXIT, OOM and HEALTH columns
+func ToggleStateColumns(app common.AppController) {
+	showState = !showState
+	app.GetDocker().SetContainerStates(showState)
+	if showState {
+		app.SetFlashSuccess("state columns shown")
+	} else {
+		app.SetFlashSuccess("state columns hidden")
+	}
+	app.RefreshCurrentView()
 }
 
 func Shell(app common.AppController, id string, asRoot bool) {
@@ -596,6 +963,246 @@ func RestartOrStart(app common.AppController, v *view.ResourceView) {
 	}, "restarting", styles.ColorStatusOrange)
 }
 
+// RecreateAction opens the run form pre-filled from the selected container.
+// Keeping the name recreates it in place, a new name clones it.
+func RecreateAction(app common.AppController, v *view.ResourceView) {
+	if app.IsReadOnly() {
+		app.AppendFlashError("read-only mode: modifications are disabled")
+		return
+	}
+
+	id, err := v.GetSelectedID()
+	if err != nil { return }
+
+	app.SetFlashPending("loading container settings...")
+	app.RunInBackground(func() {
+		spec, err := app.GetDocker().GetContainerSpec(id)
+		app.GetTviewApp().QueueUpdateDraw(func() {
+			if err != nil {
+				app.SetFlashError(fmt.Sprintf("%v", err))
+				return
+			}
+			app.SetFlashText("")
+
+			oldName, oldPorts := spec.Name, spec.Ports
+			dialogs.ShowRunForm(app, "Recreate Container", spec, func(spec dao.RunSpec, start bool) {
+				action, note := "recreating", ""
+				if spec.Name != "" && spec.Name != oldName {
+					action = "cloning"
+					// The original keeps its host ports, unless they were edited let the daemon pick new ones
+					if len(oldPorts) > 0 && slices.Equal(spec.Ports, oldPorts) {
+						spec.Ports = dao.ClearHostPorts(spec.Ports)
+						note = " (published on new host ports)"
+					}
+				}
+				app.SetFlashPending(fmt.Sprintf("%s %s...", action, oldName))
+				app.RunInBackground(func() {
+					newID, err := app.GetDocker().RecreateContainer(id, spec, start)
+					app.GetTviewApp().QueueUpdateDraw(func() {
+						if err != nil {
+							app.SetFlashError(fmt.Sprintf("%v", err))
+						} else {
+							app.SetFlashSuccess(fmt.Sprintf("%s done: %.12s%s", action, newID, note))
+						}
+						if newID != "" {
+							app.ScheduleViewHighlight(styles.TitleContainers, func(res dao.Resource) bool {
+								return res.GetID() == newID
+							}, styles.ColorStatusGreen, styles.ColorBlack, 2*time.Second)
+						}
+						app.RefreshCurrentView()
+					})
+				})
+			})
+		})
+	})
+}
+
+// PullRecreateAction pulls the image of the selected containers and
+// recreates them on the new version
+func PullRecreateAction(app common.AppController, v *view.ResourceView) {
+	ids, err := v.GetSelectedIDs()
+	if err != nil || len(ids) == 0 { return }
+
+	subject := resolveContainerSubject(v, ids[0])
+	if len(ids) > 1 {
+		subject = fmt.Sprintf("%d containers", len(ids))
+	}
+
+	dialogs.ShowConfirmation(app, "PULL & RECREATE", subject, func(force bool) {
+		app.PerformAction(func(id string) error {
+			_, err := app.GetDocker().PullAndRecreateContainer(id)
+			return err
+		}, "updating", styles.ColorStatusOrange)
+	})
+}
+
+// LimitsAction edits CPU/memory/PIDs limits and restart policy of a live container
+func LimitsAction(app common.AppController, v *view.ResourceView) {
+	if app.IsReadOnly() {
+		app.AppendFlashError("read-only mode: modifications are disabled")
+		return
+	}
+
+	id, err := v.GetSelectedID()
+	if err != nil { return }
+	subject := resolveContainerSubject(v, id)
+
+	app.SetFlashPending("loading container limits...")
+	app.RunInBackground(func() {
+		current, err := app.GetDocker().GetContainerLimits(id)
+		app.GetTviewApp().QueueUpdateDraw(func() {
+			if err != nil {
+				app.SetFlashError(fmt.Sprintf("%v", err))
+				return
+			}
+			app.SetFlashText("")
+
+			fields := []dialogs.FormField{
+				{Name: "cpus", Label: "CPUs", Type: dialogs.FieldTypeInput, Default: current.CPUs, Placeholder: "1.5"},
+				{Name: "cpuShares", Label: "CPU shares", Type: dialogs.FieldTypeInput, Default: current.CPUShares, Placeholder: "1024"},
+				{Name: "cpuPeriod", Label: "CPU period", Type: dialogs.FieldTypeInput, Default: current.CPUPeriod, Placeholder: "100000"},
+				{Name: "cpuQuota", Label: "CPU quota", Type: dialogs.FieldTypeInput, Default: current.CPUQuota, Placeholder: "50000"},
+				{Name: "memory", Label: "Memory", Type: dialogs.FieldTypeInput, Default: current.Memory, Placeholder: "512m"},
+				{Name: "memoryReservation", Label: "Mem reservation", Type: dialogs.FieldTypeInput, Default: current.MemoryReservation, Placeholder: "256m"},
+				{Name: "memorySwap", Label: "Mem + swap", Type: dialogs.FieldTypeInput, Default: current.MemorySwap, Placeholder: "1g or -1"},
+				{Name: "pids", Label: "PIDs limit", Type: dialogs.FieldTypeInput, Default: current.PidsLimit, Placeholder: "100"},
+				{Name: "restart", Label: "Restart", Type: dialogs.FieldTypeInput, Default: current.RestartPolicy, Placeholder: "no|always|unless-stopped|on-failure:3"},
+			}
+
+			description := "Only changed fields are applied. -1 removes the CPU quota, the swap and the PIDs limit."
+			dialogs.ShowFormWithNote(app, fmt.Sprintf("Limits: %s", subject), description, fields, func(result dialogs.FormResult) {
+				limits := dao.ContainerLimits{
+					CPUs:              strings.TrimSpace(result["cpus"]),
+					CPUShares:         strings.TrimSpace(result["cpuShares"]),
+					CPUPeriod:         strings.TrimSpace(result["cpuPeriod"]),
+					CPUQuota:          strings.TrimSpace(result["cpuQuota"]),
+					Memory:            strings.TrimSpace(result["memory"]),
+					MemoryReservation: strings.TrimSpace(result["memoryReservation"]),
+					MemorySwap:        strings.TrimSpace(result["memorySwap"]),
+					PidsLimit:         strings.TrimSpace(result["pids"]),
+					RestartPolicy:     strings.TrimSpace(result["restart"]),
+				}.Changes(current)
+				if limits == (dao.ContainerLimits{}) {
+					return
+				}
+
+				app.SetFlashPending(fmt.Sprintf("updating limits of %s...", subject))
+				app.RunInBackground(func() {
+					err := app.GetDocker().UpdateContainerLimits(id, limits)
+					app.GetTviewApp().QueueUpdateDraw(func() {
+						if err != nil {
+							app.SetFlashError(fmt.Sprintf("%v", err))
+						} else {
+							app.SetFlashSuccess(fmt.Sprintf("limits of %s updated", subject))
+							app.RefreshCurrentView()
+						}
+					})
+				})
+			})
+		})
+	})
+}
+
+// PauseOrUnpause unpauses the selection if the focused container is paused, pauses it otherwise
+func PauseOrUnpause(app common.AppController, v *view.ResourceView) {
+	states := make(map[string]string)
+	for _, item := range v.Data {
+		if c, ok := asContainer(item); ok {
+			states[c.ID] = strings.ToLower(c.State)
+		}
+	}
+
+	row, _ := v.Table.GetSelection()
+	if row > 0 && row <= len(v.Data) {
+		if states[v.Data[row-1].GetID()] == "paused" {
+			app.PerformAction(func(id string) error {
+				if states[id] != "paused" {
+					return nil
+				}
+				return app.GetDocker().UnpauseContainer(id)
+			}, "unpausing", styles.ColorStatusYellow)
+			return
+		}
+	}
+
+	app.PerformAction(func(id string) error {
+		if states[id] == "paused" {
+			return nil
+		}
+		return app.GetDocker().PauseContainer(id)
+	}, "pausing", styles.ColorStatusYellow)
+}
+
+var killSignals = []dialogs.PickerItem{
+	{Label: "SIGTERM", Value: "SIGTERM", Description: "Graceful termination"},
+	{Label: "SIGKILL", Value: "SIGKILL", Description: "Immediate termination"},
+	{Label: "SIGHUP", Value: "SIGHUP", Description: "Reload configuration (nginx, haproxy...)"},
+	{Label: "SIGINT", Value: "SIGINT", Description: "Interrupt"},
+	{Label: "SIGQUIT", Value: "SIGQUIT", Description: "Quit (graceful shutdown for nginx)"},
+	{Label: "SIGUSR1", Value: "SIGUSR1", Description: "User-defined 1 (reopen logs...)"},
+	{Label: "SIGUSR2", Value: "SIGUSR2", Description: "User-defined 2"},
+	{Label: "SIGWINCH", Value: "SIGWINCH", Description: "Graceful worker shutdown (apache, nginx)"},
+}
+
+// KillAction sends a chosen signal to the selected containers
+func KillAction(app common.AppController, v *view.ResourceView) {
+	ids, err := v.GetSelectedIDs()
+	if err != nil || len(ids) == 0 { return }
+
+	subject := resolveContainerSubject(v, ids[0])
+	if len(ids) > 1 {
+		subject = fmt.Sprintf("%d containers", len(ids))
+	}
+
+	dialogs.ShowPicker(app, fmt.Sprintf("Send Signal: %s", subject), killSignals, func(signal string) {
+		color := styles.ColorStatusOrange
+		if signal == "SIGKILL" || signal == "SIGTERM" {
+			color = styles.ColorStatusRed
+		}
+		app.PerformAction(func(id string) error {
+			return app.GetDocker().KillContainer(id, signal)
+		}, strings.ToLower(signal), color)
+	})
+}
+
+// RenameAction renames the focused container
+func RenameAction(app common.AppController, v *view.ResourceView) {
+	if app.IsReadOnly() {
+		app.AppendFlashError("read-only mode: modifications are disabled")
+		return
+	}
+
+	id, err := v.GetSelectedID()
+	if err != nil { return }
+
+	current := ""
+	row, _ := v.Table.GetSelection()
+	if row > 0 && row <= len(v.Data) {
+		if c, ok := asContainer(v.Data[row-1]); ok {
+			current = c.Names
+		}
+	}
+
+	dialogs.ShowInput(app, "Rename Container", "New Name: ", current, func(name string) {
+		name = strings.TrimSpace(name)
+		if name == "" || name == current {
+			return
+		}
+		app.SetFlashPending(fmt.Sprintf("renaming %s to %s...", current, name))
+		app.RunInBackground(func() {
+			err := app.GetDocker().RenameContainer(id, name)
+			app.GetTviewApp().QueueUpdateDraw(func() {
+				if err != nil {
+					app.SetFlashError(fmt.Sprintf("%v", err))
+				} else {
+					app.SetFlashSuccess(fmt.Sprintf("renamed %s to %s", current, name))
+					app.RefreshCurrentView()
+				}
+			})
+		})
+	})
+}
+
 func StopAction(app common.AppController, v *view.ResourceView) {
 	app.PerformAction(func(id string) error {
 		return app.GetDocker().StopContainer(id)
@@ -676,9 +1283,11 @@ func NetworksPicker(app common.AppController, v *view.ResourceView) {
 		}
 	}
 
+	names := make(map[string]string)
 	var items []dialogs.MultiPickerItem
 	for _, res := range allNetworks {
 		if n, ok := res.(dao.Network); ok {
+			names[n.ID] = n.Name
 			items = append(items, dialogs.MultiPickerItem{
 				ID:       n.ID,
 				Label:    n.Name,
@@ -688,6 +1297,7 @@ func NetworksPicker(app common.AppController, v *view.ResourceView) {
 	}
 
 	subject := resolveContainerSubject(v, id)
+	name := strings.SplitN(subject, "@", 2)[0]
 
 	dialogs.ShowMultiPicker(app, "Attach Networks", subject, items, func(selected []string) {
 		selectedMap := make(map[string]bool)
@@ -716,31 +1326,49 @@ func NetworksPicker(app common.AppController, v *view.ResourceView) {
 			return
 		}
 
-		app.SetFlashPending("updating networks...")
-		app.RunInBackground(func() {
-			var errs []string
+		apply := func(specs map[string]dao.NetworkConnectSpec) {
+			app.SetFlashPending("updating networks...")
+			app.RunInBackground(func() {
+				var errs []string
 
-			for _, netID := range toConnect {
-				if err := app.GetDocker().ConnectNetwork(netID, id); err != nil {
-					errs = append(errs, fmt.Sprintf("connect %s: %v", netID, err))
+				for _, netID := range toConnect {
+					if err := app.GetDocker().ConnectNetwork(netID, id, specs[netID]); err != nil {
+						errs = append(errs, fmt.Sprintf("connect %s: %v", netID, err))
+					}
 				}
-			}
 
-			for _, netID := range toDisconnect {
-				if err := app.GetDocker().DisconnectNetwork(netID, id); err != nil {
-					errs = append(errs, fmt.Sprintf("disconnect %s: %v", netID, err))
+				for _, netID := range toDisconnect {
+					if err := app.GetDocker().DisconnectNetwork(netID, id); err != nil {
+						errs = append(errs, fmt.Sprintf("disconnect %s: %v", netID, err))
+					}
 				}
-			}
 
-			app.GetTviewApp().QueueUpdateDraw(func() {
-				if len(errs) > 0 {
-					app.SetFlashError(strings.Join(errs, "; "))
-				} else {
-					app.SetFlashSuccess("networks updated")
-					app.RefreshCurrentView()
-				}
+				app.GetTviewApp().QueueUpdateDraw(func() {
+					if len(errs) > 0 {
+						app.SetFlashError(strings.Join(errs, "; "))
+					} else {
+						app.SetFlashSuccess("networks updated")
+						app.RefreshCurrentView()
+					}
+				})
 			})
-		})
+		}
+
+		// Ask for the endpoint settings (aliases, static IPs, links) of each new network
+		specs := make(map[string]dao.NetworkConnectSpec)
+		var askNext func(i int)
+		askNext = func(i int) {
+			if i == len(toConnect) {
+				apply(specs)
+				return
+			}
+			netID := toConnect[i]
+			dialogs.ShowNetworkConnectForm(app, names[netID], name, func(spec dao.NetworkConnectSpec) {
+				specs[netID] = spec
+				askNext(i + 1)
+			})
+		}
+		askNext(0)
 	})
 }
 
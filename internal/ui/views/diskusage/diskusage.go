@@ -0,0 +1,237 @@
+package diskusage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jr-k/d4s/internal/dao"
+	daoCommon "github.com/jr-k/d4s/internal/dao/common"
+	"github.com/jr-k/d4s/internal/ui/common"
+	"github.com/jr-k/d4s/internal/ui/components/inspect"
+	"github.com/jr-k/d4s/internal/ui/components/view"
+	"github.com/jr-k/d4s/internal/ui/dialogs"
+	"github.com/jr-k/d4s/internal/ui/styles"
+	"github.com/rivo/tview"
+)
+
+var Headers = []string{"KIND", "NAME", "SIZE", "REASON", "CREATED"}
+
+// DiskUsage is an expensive call (volume sizes), keep the analysis for a while
+const analysisTTL = 30 * time.Second
+
+// previewLimit caps the number of items listed in the prune preview
+const previewLimit = 12
+
+var (
+	mu         sync.Mutex
+	unusedDays = 30
+	analysis   *dao.DiskUsageAnalysis
+	analyzedAt time.Time
+)
+
+func Fetch(app common.AppController, v *view.ResourceView) ([]dao.Resource, error) {
+	mu.Lock()
+	days := unusedDays
+	cached := analysis
+	fresh := cached != nil && cached.UnusedDays == days && time.Since(analyzedAt) < analysisTTL
+	mu.Unlock()
+
+	if !fresh {
+		a, err := app.GetDocker().AnalyzeDiskUsage(days)
+		if err != nil {
+			return nil, err
+		}
+		mu.Lock()
+		analysis, analyzedAt = a, time.Now()
+		mu.Unlock()
+		cached = a
+	}
+
+	v.TitleInfo = fmt.Sprintf("%s reclaimable", daoCommon.FormatBytes(cached.Reclaimable()))
+
+	res := make([]dao.Resource, len(cached.Candidates))
+	for i, c := range cached.Candidates {
+		res[i] = c
+	}
+	return res, nil
+}
+
+// invalidate forces a new analysis on the next fetch
+func invalidate() {
+	mu.Lock()
+	analyzedAt = time.Time{}
+	mu.Unlock()
+}
+
+func GetShortcuts() []string {
+	return []string{
+		common.FormatSCHeader("d", "Report"),
+		common.FormatSCHeader("a", "Unused Days"),
+		common.FormatSCHeader("r", "Rescan"),
+		common.FormatSCHeader("ctrl-d", "Prune"),
+	}
+}
+
+func InputHandler(v *view.ResourceView, event *tcell.EventKey) *tcell.EventKey {
+	app := v.App
+
+	if event.Key() == tcell.KeyCtrlD {
+		PruneAction(app, v)
+		return nil
+	}
+
+	switch event.Rune() {
+	case 'd':
+		Report(app)
+		return nil
+	case 'a':
+		UnusedDaysAction(app)
+		return nil
+	case 'r':
+		invalidate()
+		app.SetFlashPending("scanning disk usage...")
+		app.RefreshCurrentView()
+		return nil
+	}
+
+	return event
+}
+
+// UnusedDaysAction changes the age after which unused images are proposed
+func UnusedDaysAction(app common.AppController) {
+	mu.Lock()
+	current := unusedDays
+	mu.Unlock()
+
+	dialogs.ShowInput(app, "Unused Images", "Older than (days):", strconv.Itoa(current), func(text string) {
+		days, err := strconv.Atoi(strings.TrimSpace(text))
+		if err != nil || days < 0 {
+			app.SetFlashError(fmt.Sprintf("invalid number of days: %q", text))
+			return
+		}
+		mu.Lock()
+		unusedDays = days
+		mu.Unlock()
+		app.RefreshCurrentView()
+	})
+}
+
+// Report opens the per-category summary, duplicate tags and unused images
+func Report(app common.AppController) {
+	mu.Lock()
+	a := analysis
+	mu.Unlock()
+	if a == nil {
+		app.SetFlashError("disk usage not scanned yet")
+		return
+	}
+	app.OpenInspector(inspect.NewTextInspector("Report", "disk usage", formatReport(a), "text"))
+}
+
+func formatReport(a *dao.DiskUsageAnalysis) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf(" [%s::b]%-15s %7s %7s %11s %s[-::-]\n", styles.TagCyan, "TYPE", "TOTAL", "ACTIVE", "SIZE", "RECLAIMABLE"))
+	for _, c := range a.Categories {
+		pct := int64(0)
+		if c.Size > 0 {
+			pct = c.Reclaimable * 100 / c.Size
+		}
+		sb.WriteString(fmt.Sprintf(" %-15s %7d %7d %11s %s [%s](%d%%)[-]\n", c.Name, c.Total, c.Active,
+			daoCommon.FormatBytes(c.Size), daoCommon.FormatBytes(c.Reclaimable), styles.TagDim, pct))
+	}
+	sb.WriteString(fmt.Sprintf(" [%s]Image layers on disk: %s[-]\n", styles.TagDim, daoCommon.FormatBytes(a.LayersSize)))
+
+	unused := a.Unused
+	var unusedSize int64
+	for _, c := range unused {
+		unusedSize += c.Size
+	}
+	sb.WriteString(fmt.Sprintf("\n [%s::b]IMAGES UNUSED FOR %d+ DAYS[-::-] [%s](%d, %s)[-]\n", styles.TagCyan, a.UnusedDays, styles.TagDim, len(unused), daoCommon.FormatBytes(unusedSize)))
+	if len(unused) == 0 {
+		sb.WriteString(fmt.Sprintf(" [%s]none[-]\n", styles.TagDim))
+	}
+	for _, c := range unused {
+		sb.WriteString(fmt.Sprintf(" %-11s %s [%s](%s)[-]\n", daoCommon.FormatBytes(c.Size), tview.Escape(c.Name), styles.TagDim, c.Reason))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n [%s::b]DUPLICATE TAGS[-::-] [%s](%d)[-]\n", styles.TagCyan, styles.TagDim, len(a.Duplicates)))
+	if len(a.Duplicates) == 0 {
+		sb.WriteString(fmt.Sprintf(" [%s]none[-]\n", styles.TagDim))
+	}
+	for _, d := range a.Duplicates {
+		sb.WriteString(fmt.Sprintf(" %.12s %-11s %s\n", d.ID, daoCommon.FormatBytes(d.Size), tview.Escape(strings.Join(d.Tags, ", "))))
+	}
+
+	return sb.String()
+}
+
+// PruneAction previews (dry run) the removal of the selected candidates and
+// only deletes them once confirmed
+func PruneAction(app common.AppController, v *view.ResourceView) {
+	ids, err := v.GetSelectedIDs()
+	if err != nil || len(ids) == 0 {
+		return
+	}
+	if app.IsReadOnly() {
+		app.AppendFlashError("read-only mode: modifications are disabled")
+		return
+	}
+
+	idMap := make(map[string]bool)
+	for _, id := range ids {
+		idMap[id] = true
+	}
+	var selected []dao.DiskUsageCandidate
+	var total int64
+	for _, item := range v.Data {
+		if c, ok := item.(dao.DiskUsageCandidate); ok && idMap[c.GetID()] {
+			selected = append(selected, c)
+			if !c.Shared {
+				total += c.Size
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Dry run: %d item(s), about %s reclaimable\n", len(selected), daoCommon.FormatBytes(total)))
+	for i, c := range selected {
+		if i == previewLimit {
+			sb.WriteString(fmt.Sprintf("... and %d more\n", len(selected)-previewLimit))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("- %s %s (%s)\n", c.Kind, c.Name, daoCommon.FormatBytes(c.Size)))
+	}
+
+	fields := []dialogs.FormField{
+		{Name: "confirm", Label: "Delete", Type: dialogs.FieldTypeCheckbox, Default: "false"},
+	}
+	dialogs.ShowFormWithNote(app, "Prune Preview", strings.TrimRight(sb.String(), "\n"), fields, func(result dialogs.FormResult) {
+		if result["confirm"] != "true" {
+			app.SetFlashText(fmt.Sprintf("[%s]dry run only, nothing was deleted (check Delete to prune)", styles.TagDim))
+			return
+		}
+
+		app.SetFlashPending(fmt.Sprintf("pruning %d item(s)...", len(selected)))
+		app.RunInBackground(func() {
+			reclaimed, errs := app.GetDocker().RemoveDiskUsageCandidates(selected)
+			invalidate()
+			app.GetTviewApp().QueueUpdateDraw(func() {
+				v.SelectedIDs = make(map[string]bool)
+				if len(errs) > 0 {
+					dialogs.ShowResultModal(app, "prune", len(selected)-len(errs), errs)
+				} else {
+					app.SetFlashSuccess(fmt.Sprintf("pruned %d item(s), %s reclaimed", len(selected), daoCommon.FormatBytes(reclaimed)))
+				}
+				app.RefreshCurrentView()
+			})
+		})
+	})
+}
@@ -1,24 +1,30 @@
 package images
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"os/signal"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/jr-k/d4s/internal/config"
 	"github.com/jr-k/d4s/internal/dao"
+	daoCommon "github.com/jr-k/d4s/internal/dao/common"
 	"github.com/jr-k/d4s/internal/ui/common"
 	"github.com/jr-k/d4s/internal/ui/components/inspect"
 	"github.com/jr-k/d4s/internal/ui/components/view"
 	"github.com/jr-k/d4s/internal/ui/dialogs"
 	"github.com/jr-k/d4s/internal/ui/styles"
+	"github.com/rivo/tview"
 )
 
-var Headers = []string{"ID", "TAGS", "SIZE", "CONTAINERS", "CREATED"}
+var Headers = []string{"ID", "TAGS", "SIZE", "CONTAINERS", "CREATED", "VULNS"}
 
 func Fetch(app common.AppController, v *view.ResourceView) ([]dao.Resource, error) {
 	images, err := app.GetDocker().ListImages()
@@ -48,7 +54,18 @@ func GetShortcuts() []string {
 	return []string{
 		common.FormatSCHeader("d", "Describe"),
 		common.FormatSCHeader("v", "Dive"),
+		common.FormatSCHeader("shift-l", "Layers"),
+		common.FormatSCHeader("shift-s", "Scan"),
+		common.FormatSCHeader("b", "SBOM"),
 		common.FormatSCHeader("r", "Pull"),
+		common.FormatSCHeader("p", "Progress"),
+		common.FormatSCHeader("shift-r", "Run"),
+		common.FormatSCHeader("shift-b", "Build"),
+		common.FormatSCHeader("t", "Tag"),
+		common.FormatSCHeader("shift-t", "Remote Tags"),
+		common.FormatSCHeader("shift-u", "Push"),
+		common.FormatSCHeader("s", "Save"),
+		common.FormatSCHeader("l", "Load"),
 		common.FormatSCHeader("shift-p", "Prune"),
 		common.FormatSCHeader("ctrl-d", "Delete"),
 	}
@@ -64,9 +81,42 @@ func InputHandler(v *view.ResourceView, event *tcell.EventKey) *tcell.EventKey {
 	case 'v':
 		DiveAction(app, v)
 		return nil
+	case 'L':
+		LayersAction(app, v)
+		return nil
+	case 'S':
+		ScanAction(app, v)
+		return nil
+	case 'b':
+		SBOMAction(app, v)
+		return nil
+	case 'B':
+		BuildAction(app)
+		return nil
 	case 'r':
 		PullAction(app, v)
 		return nil
+	case 'R':
+		RunAction(app, v)
+		return nil
+	case 'p':
+		PullProgress(app, v)
+		return nil
+	case 't':
+		TagAction(app, v)
+		return nil
+	case 'T':
+		RemoteTagsAction(app, v)
+		return nil
+	case 'U':
+		PushAction(app, v)
+		return nil
+	case 's':
+		SaveAction(app, v)
+		return nil
+	case 'l':
+		LoadAction(app)
+		return nil
 	case 'P':
 		PruneAction(app)
 		return nil
@@ -119,6 +169,35 @@ func EnterAction(app common.AppController, v *view.ResourceView) {
 	app.SwitchTo(styles.TitleContainers)
 }
 
+// RemoteTagsAction browses the tags of the selected image repository in its registry
+func RemoteTagsAction(app common.AppController, v *view.ResourceView) {
+	row, _ := v.Table.GetSelection()
+	if row <= 0 || row > len(v.Data) {
+		return
+	}
+	img, ok := v.Data[row-1].(dao.Image)
+	if !ok || !hasTag(img) {
+		app.SetFlashError("image has no repository")
+		return
+	}
+
+	repo, err := dao.ImageRepositoryName(img.RepoTag)
+	if err != nil {
+		app.SetFlashError(fmt.Sprintf("%v", err))
+		return
+	}
+
+	app.SetActiveScope(&common.Scope{
+		Type:       "repository",
+		Value:      repo,
+		Label:      repo,
+		OriginView: styles.TitleImages,
+		Parent:     app.GetActiveScope(),
+	})
+	app.SetFlashPending(fmt.Sprintf("querying registry for %s...", repo))
+	app.SwitchTo(styles.TitleRemoteTags)
+}
+
 func PruneAction(app common.AppController) {
 	dialogs.ShowConfirmation(app, "PRUNE", "Images", func(force bool) {
 		app.SetFlashPending("pruning images...")
@@ -148,12 +227,15 @@ func PullAction(app common.AppController, v *view.ResourceView) {
 	}
 
 	count := 0
+	lastTag := ""
 	for _, item := range v.Data {
 		if idMap[item.GetID()] {
 			if img, ok := item.(dao.Image); ok {
 				if img.RepoTag != "" && img.RepoTag != "<none>" {
 					count++
 					tag := img.RepoTag
+					lastTag = tag
+					app.GetDocker().StartPullProgress(tag)
 
 					app.RunInBackground(func() {
 						err := app.GetDocker().PullImage(tag)
@@ -169,6 +251,11 @@ func PullAction(app common.AppController, v *view.ResourceView) {
 		}
 	}
 
+	if count == 1 {
+		// Follow a single pull in the progress inspector
+		app.OpenInspector(inspect.NewProgressInspector("Pull image", lastTag, app.GetDocker().GetPullProgress(lastTag)))
+	}
+
 	if count > 0 {
 		app.SetFlashPending(fmt.Sprintf("Pulling %d image(s)...", count))
 		// Force refresh to show status
@@ -181,6 +268,404 @@ func PullAction(app common.AppController, v *view.ResourceView) {
 	}
 }
 
+// RunAction opens the container creation form for the selected image
+func RunAction(app common.AppController, v *view.ResourceView) {
+	if app.IsReadOnly() {
+		app.AppendFlashError("read-only mode: modifications are disabled")
+		return
+	}
+
+	row, _ := v.Table.GetSelection()
+	if row <= 0 || row > len(v.Data) {
+		return
+	}
+	img, ok := v.Data[row-1].(dao.Image)
+	if !ok {
+		return
+	}
+
+	ref := img.RepoTag
+	if ref == "" || ref == "<none>" {
+		ref = img.ID
+	}
+
+	dialogs.ShowRunForm(app, "Run Container", dao.RunSpec{Image: ref}, func(spec dao.RunSpec, start bool) {
+		app.SetFlashPending(fmt.Sprintf("creating container from %s...", spec.Image))
+		app.RunInBackground(func() {
+			id, err := app.GetDocker().CreateContainer(spec)
+			if err == nil && start {
+				if err = app.GetDocker().StartContainer(id); err != nil {
+					err = fmt.Errorf("created %.12s but failed to start: %w", id, err)
+				}
+			}
+			app.GetTviewApp().QueueUpdateDraw(func() {
+				if err != nil {
+					app.SetFlashError(fmt.Sprintf("%v", err))
+					return
+				}
+				app.SetFlashSuccess(fmt.Sprintf("container %.12s created from %s", id, spec.Image))
+				app.ScheduleViewHighlight(styles.TitleContainers, func(res dao.Resource) bool {
+					return res.GetID() == id
+				}, styles.ColorStatusGreen, styles.ColorBlack, 2*time.Second)
+				app.RefreshCurrentView()
+			})
+		})
+	})
+}
+
+// PullProgress opens the progress of the latest pull of the focused image
+func PullProgress(app common.AppController, v *view.ResourceView) {
+	row, _ := v.Table.GetSelection()
+	if row <= 0 || row > len(v.Data) {
+		return
+	}
+	img, ok := v.Data[row-1].(dao.Image)
+	if !ok {
+		return
+	}
+
+	// Latest transfer wins when the image was both pulled and pushed
+	pull := app.GetDocker().GetPullProgress(img.RepoTag)
+	push := app.GetDocker().GetPushProgress(img.RepoTag)
+	switch {
+	case push != nil && (pull == nil || push.Started.After(pull.Started)):
+		app.OpenInspector(inspect.NewProgressInspector("Push image", img.RepoTag, push))
+	case pull != nil:
+		app.OpenInspector(inspect.NewProgressInspector("Pull image", img.RepoTag, pull))
+	default:
+		app.SetFlashError("no pull or push in this session for this image")
+	}
+}
+
+// selectedImages returns the images of the current selection (or focused row)
+func selectedImages(v *view.ResourceView) []dao.Image {
+	ids, err := v.GetSelectedIDs()
+	if err != nil || len(ids) == 0 {
+		return nil
+	}
+	idMap := make(map[string]bool)
+	for _, id := range ids {
+		idMap[id] = true
+	}
+
+	var out []dao.Image
+	for _, item := range v.Data {
+		if img, ok := item.(dao.Image); ok && idMap[img.ID] {
+			out = append(out, img)
+		}
+	}
+	return out
+}
+
+func hasTag(img dao.Image) bool {
+	return img.RepoTag != "" && img.RepoTag != "<none>"
+}
+
+// TagAction adds a tag to the selected image, or moves several images under a
+// registry/namespace prefix (e.g. registry.local:5000/mirror)
+func TagAction(app common.AppController, v *view.ResourceView) {
+	imgs := selectedImages(v)
+	if len(imgs) == 0 {
+		return
+	}
+	if app.IsReadOnly() {
+		app.AppendFlashError("read-only mode: modifications are disabled")
+		return
+	}
+
+	run := func(targets map[string]string) {
+		app.SetFlashPending(fmt.Sprintf("tagging %d image(s)...", len(targets)))
+		app.RunInBackground(func() {
+			var errs []string
+			for source, target := range targets {
+				if err := app.GetDocker().TagImage(source, target); err != nil {
+					errs = append(errs, fmt.Sprintf("%s: %v", target, err))
+				}
+			}
+			app.GetTviewApp().QueueUpdateDraw(func() {
+				if len(errs) > 0 {
+					app.SetFlashError(strings.Join(errs, "; "))
+				} else if len(targets) == 1 {
+					for _, target := range targets {
+						app.SetFlashSuccess(fmt.Sprintf("tagged %s", target))
+					}
+				} else {
+					app.SetFlashSuccess(fmt.Sprintf("tagged %d images", len(targets)))
+				}
+				app.RefreshCurrentView()
+			})
+		})
+	}
+
+	if len(imgs) == 1 {
+		img := imgs[0]
+		initial := ""
+		if hasTag(img) {
+			initial = img.RepoTag
+		}
+		dialogs.ShowInput(app, "Tag Image", "Target:", initial, func(text string) {
+			target := strings.TrimSpace(text)
+			if target == "" || target == img.RepoTag {
+				return
+			}
+			run(map[string]string{img.ID: target})
+		})
+		return
+	}
+
+	dialogs.ShowInput(app, fmt.Sprintf("Tag %d Images", len(imgs)), "Prefix:", "", func(text string) {
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		targets := make(map[string]string)
+		for _, img := range imgs {
+			if !hasTag(img) {
+				continue
+			}
+			target, err := dao.ImageWithRepositoryPrefix(img.RepoTag, text)
+			if err != nil {
+				app.SetFlashError(fmt.Sprintf("%v", err))
+				return
+			}
+			targets[img.ID] = target
+		}
+		if len(targets) == 0 {
+			app.SetFlashError("no tagged image in selection")
+			return
+		}
+		run(targets)
+	})
+}
+
+// PushAction pushes the selected images to their registry
+func PushAction(app common.AppController, v *view.ResourceView) {
+	var refs []string
+	for _, img := range selectedImages(v) {
+		if hasTag(img) {
+			refs = append(refs, img.RepoTag)
+		}
+	}
+	if len(refs) == 0 {
+		app.SetFlashError("no tagged image to push")
+		return
+	}
+	if app.IsReadOnly() {
+		app.AppendFlashError("read-only mode: modifications are disabled")
+		return
+	}
+
+	dialogs.ShowConfirmation(app, "PUSH", strings.Join(refs, ", "), func(force bool) {
+		for _, ref := range refs {
+			app.GetDocker().StartPushProgress(ref)
+			app.RunInBackground(func() {
+				err := app.GetDocker().PushImage(ref)
+				app.GetTviewApp().QueueUpdateDraw(func() {
+					if err != nil {
+						app.SetFlashError(fmt.Sprintf("Push failed: %v", err))
+					}
+					app.RefreshCurrentView()
+				})
+			})
+		}
+
+		if len(refs) == 1 {
+			app.OpenInspector(inspect.NewProgressInspector("Push image", refs[0], app.GetDocker().GetPushProgress(refs[0])))
+		}
+		app.SetFlashPending(fmt.Sprintf("Pushing %d image(s)...", len(refs)))
+	})
+}
+
+// SaveAction writes the selected images to a local tar archive (docker save)
+func SaveAction(app common.AppController, v *view.ResourceView) {
+	imgs := selectedImages(v)
+	if len(imgs) == 0 {
+		return
+	}
+
+	// Save by reference so the archive keeps the tags
+	refs := make([]string, len(imgs))
+	for i, img := range imgs {
+		refs[i] = img.ID
+		if hasTag(img) {
+			refs[i] = img.RepoTag
+		}
+	}
+
+	initial := "images.tar"
+	if len(imgs) == 1 && hasTag(imgs[0]) {
+		initial = strings.NewReplacer("/", "_", ":", "_").Replace(imgs[0].RepoTag) + ".tar"
+	}
+
+	dialogs.ShowInput(app, fmt.Sprintf("Save %d Image(s)", len(refs)), "Path:", initial, func(text string) {
+		path := daoCommon.ExpandPath(strings.TrimSpace(text))
+		if path == "" {
+			return
+		}
+		save := func() {
+			app.SetFlashPending(fmt.Sprintf("saving %d image(s) to %s...", len(refs), path))
+			app.RunInBackground(func() {
+				size, err := app.GetDocker().SaveImages(refs, path)
+				app.GetTviewApp().QueueUpdateDraw(func() {
+					if err != nil {
+						app.SetFlashError(fmt.Sprintf("Save failed: %v", err))
+						return
+					}
+					app.SetFlashSuccess(fmt.Sprintf("saved %d image(s) to %s (%s)", len(refs), daoCommon.ShortenPath(path), daoCommon.FormatBytes(size)))
+				})
+			})
+		}
+
+		info, err := os.Stat(path)
+		switch {
+		case err != nil:
+			save()
+		case info.IsDir():
+			app.SetFlashError(fmt.Sprintf("%s is a directory", text))
+		default:
+			dialogs.ShowConfirmation(app, "OVERWRITE", daoCommon.ShortenPath(path), func(force bool) {
+				save()
+			})
+		}
+	})
+}
+
+// LoadAction imports images from a local tar archive (docker load)
+func LoadAction(app common.AppController) {
+	if app.IsReadOnly() {
+		app.AppendFlashError("read-only mode: modifications are disabled")
+		return
+	}
+
+	dialogs.ShowInput(app, "Load Images", "Path:", "", func(text string) {
+		path := daoCommon.ExpandPath(strings.TrimSpace(text))
+		if path == "" {
+			return
+		}
+		app.SetFlashPending(fmt.Sprintf("loading images from %s...", path))
+		app.RunInBackground(func() {
+			loaded, err := app.GetDocker().LoadImages(path)
+			app.GetTviewApp().QueueUpdateDraw(func() {
+				if err != nil {
+					app.SetFlashError(fmt.Sprintf("Load failed: %v", err))
+					return
+				}
+				app.SetFlashSuccess(fmt.Sprintf("loaded %s", strings.Join(loaded, ", ")))
+
+				refs := make(map[string]bool)
+				for _, ref := range loaded {
+					refs[ref] = true
+				}
+				app.ScheduleViewHighlight(styles.TitleImages, func(res dao.Resource) bool {
+					img, ok := res.(dao.Image)
+					return ok && (refs[img.RepoTag] || refs["sha256:"+img.ID])
+				}, styles.ColorStatusGreen, styles.ColorBlack, 2*time.Second)
+				app.RefreshCurrentView()
+			})
+		})
+	})
+}
+
+// LayersAction opens the native layer history and config summary of the selected image
+func LayersAction(app common.AppController, v *view.ResourceView) {
+	id, err := v.GetSelectedID()
+	if err != nil { return }
+
+	subject := id
+	if len(id) > 12 {
+		subject = id[:12]
+	}
+	row, _ := v.Table.GetSelection()
+	if row > 0 && row <= len(v.Data) {
+		if img, ok := v.Data[row-1].(dao.Image); ok && img.RepoTag != "" && img.RepoTag != "<none>" {
+			subject = img.RepoTag
+		}
+	}
+
+	inspector := inspect.NewTextInspector("Layers image", subject, fmt.Sprintf(" [%s]Loading image history...\n", styles.TagAccent), "text")
+	app.OpenInspector(inspector)
+
+	app.RunInBackground(func() {
+		details, err := app.GetDocker().GetImageDetails(id)
+		app.GetTviewApp().QueueUpdateDraw(func() {
+			if err != nil {
+				inspector.Viewer.Update(fmt.Sprintf("Error: %v", err), "text")
+				return
+			}
+			inspector.Viewer.Update(formatLayers(details), "text")
+		})
+	})
+}
+
+func formatLayers(d *dao.ImageDetails) string {
+	var sb strings.Builder
+	field := func(name, value string) {
+		if value == "" {
+			return
+		}
+		sb.WriteString(fmt.Sprintf(" [%s]%-13s[-] %s\n", styles.TagDim, name+":", tview.Escape(value)))
+	}
+	list := func(name string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		sb.WriteString(fmt.Sprintf(" [%s]%s:[-]\n", styles.TagDim, name))
+		for _, value := range values {
+			sb.WriteString(fmt.Sprintf("   %s\n", tview.Escape(value)))
+		}
+	}
+
+	platform := d.Os + "/" + d.Architecture
+	if d.Variant != "" {
+		platform += "/" + d.Variant
+	}
+
+	sb.WriteString(fmt.Sprintf(" [%s::b]CONFIG[-::-]\n", styles.TagCyan))
+	field("ID", d.ID)
+	field("Tags", strings.Join(d.RepoTags, ", "))
+	field("Platform", platform)
+	field("Size", daoCommon.FormatBytes(d.Size))
+	field("Created", d.Created)
+	field("User", d.User)
+	field("WorkingDir", d.WorkingDir)
+	field("Entrypoint", daoCommon.JoinCommandLine(d.Entrypoint))
+	field("Cmd", daoCommon.JoinCommandLine(d.Cmd))
+	field("Ports", strings.Join(d.ExposedPorts, ", "))
+	list("Env", d.Env)
+	if len(d.Labels) > 0 {
+		keys := make([]string, 0, len(d.Labels))
+		for k := range d.Labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		labels := make([]string, len(keys))
+		for i, k := range keys {
+			labels[i] = k + "=" + d.Labels[k]
+		}
+		list("Labels", labels)
+	}
+
+	empty := 0
+	for _, l := range d.Layers {
+		if l.Empty {
+			empty++
+		}
+	}
+	sb.WriteString(fmt.Sprintf("\n [%s::b]LAYERS[-::-] [%s](%d, %d empty)[-]\n", styles.TagCyan, styles.TagDim, len(d.Layers), empty))
+	sb.WriteString(fmt.Sprintf(" [%s::b]%-4s %-10s %-11s %-17s %s[-::-]\n", styles.TagCyan, "#", "SIZE", "CUMULATIVE", "CREATED", "INSTRUCTION"))
+	for i, l := range d.Layers {
+		size := daoCommon.FormatBytes(l.Size)
+		color := styles.TagFg
+		if l.Empty {
+			size = "empty"
+			color = styles.TagDim
+		}
+		sb.WriteString(fmt.Sprintf(" [%s]%-4d %-10s %-11s %-17s %s[-]\n", color, i+1, size,
+			daoCommon.FormatBytes(l.Cumulative), daoCommon.FormatTime(l.Created.Unix()), tview.Escape(l.CreatedBy)))
+	}
+
+	return sb.String()
+}
+
 func Inspect(app common.AppController, id string) {
 	subject := id
 	if len(id) > 12 {
@@ -259,10 +744,210 @@ func Remove(id string, force bool, app common.AppController) error {
 	return app.GetDocker().RemoveImage(id, force)
 }
 
+// ScanAction runs the vulnerability scanner on the selected images. A single
+// image opens its report, several are scanned one after the other in the background.
+func ScanAction(app common.AppController, v *view.ResourceView) {
+	imgs := selectedImages(v)
+	if len(imgs) == 0 {
+		return
+	}
+
+	cfg := app.GetConfig().D4S.Scanner
+	opts := dao.ScanOptions{Tool: cfg.Tool, Mode: cfg.Mode, Image: cfg.Image, Socket: app.GetConfig().D4S.DockerSocket, NoRun: app.IsReadOnly()}
+	target := func(img dao.Image) string {
+		if hasTag(img) {
+			return img.RepoTag
+		}
+		return img.ID
+	}
+
+	if len(imgs) > 1 {
+		app.SetFlashPending(fmt.Sprintf("scanning %d images...", len(imgs)))
+		app.RunInBackground(func() {
+			var errs []string
+			for _, img := range imgs {
+				if _, err := app.GetDocker().ScanImage(img.ID, target(img), opts); err != nil {
+					errs = append(errs, fmt.Sprintf("%s: %v", target(img), err))
+				}
+			}
+			app.GetTviewApp().QueueUpdateDraw(func() {
+				if len(errs) > 0 {
+					dialogs.ShowResultModal(app, "scan", len(imgs)-len(errs), errs)
+				} else {
+					app.SetFlashSuccess(fmt.Sprintf("scanned %d images", len(imgs)))
+				}
+				app.RefreshCurrentView()
+			})
+		})
+		app.RefreshCurrentView()
+		return
+	}
+
+	img := imgs[0]
+	inspector := inspect.NewTextInspector("Scan image", target(img), fmt.Sprintf(" [%s]Scanning %s (the first run downloads the vulnerability database)...\n", styles.TagAccent, tview.Escape(target(img))), "text")
+	app.OpenInspector(inspector)
+
+	app.RunInBackground(func() {
+		report, err := app.GetDocker().ScanImage(img.ID, target(img), opts)
+		app.GetTviewApp().QueueUpdateDraw(func() {
+			if err != nil {
+				inspector.Viewer.Update(fmt.Sprintf("Error: %s", tview.Escape(err.Error())), "text")
+				return
+			}
+			inspector.Viewer.Update(formatScanReport(report), "text")
+			app.RefreshCurrentView()
+		})
+	})
+}
+
+func formatScanReport(r *dao.ScanReport) string {
+	var sb strings.Builder
+	counts := r.Counts()
+
+	sb.WriteString(fmt.Sprintf(" [%s]%s, %s, %d vulnerabilities[-]\n", styles.TagDim, r.Tool, r.ScannedAt.Format("2006-01-02 15:04:05"), len(r.Vulns)))
+	if len(r.Vulns) == 0 {
+		sb.WriteString(fmt.Sprintf("\n [%s]No known vulnerabilities[-]\n", styles.ColorStatusGreen.String()))
+		return sb.String()
+	}
+
+	i := 0
+	for _, sev := range dao.ScanSeverities {
+		if counts[sev] == 0 {
+			continue
+		}
+		color := styles.TagCyan
+		switch sev {
+		case "CRITICAL":
+			color = styles.TagError
+		case "HIGH":
+			color = styles.ColorStatusOrange.String()
+		}
+		sb.WriteString(fmt.Sprintf("\n [%s::b]%s[-::-] [%s](%d)[-]\n", color, sev, styles.TagDim, counts[sev]))
+		sb.WriteString(fmt.Sprintf(" [%s]%-20s %-28s %-22s %-22s %s[-]\n", styles.TagDim, "ID", "PACKAGE", "INSTALLED", "FIXED", "TITLE"))
+		for ; i < len(r.Vulns) && r.Vulns[i].Severity == sev; i++ {
+			vuln := r.Vulns[i]
+			fixed := vuln.Fixed
+			if fixed == "" {
+				fixed = "-"
+			}
+			sb.WriteString(fmt.Sprintf(" %-20s %-28s %-22s %-22s %s\n", vuln.ID, tview.Escape(vuln.Package), tview.Escape(vuln.Installed), tview.Escape(fixed), tview.Escape(vuln.Title)))
+		}
+	}
+	return sb.String()
+}
+
+// Last build settings, proposed again by the next build
+var lastBuild = dao.BuildOptions{ContextDir: "."}
+
+// BuildAction builds an image from a Dockerfile and follows the BuildKit output
+func BuildAction(app common.AppController) {
+	if app.IsReadOnly() {
+		app.AppendFlashError("read-only mode: modifications are disabled")
+		return
+	}
+
+	dialogs.ShowBuildForm(app, lastBuild, func(opts dao.BuildOptions) {
+		lastBuild = opts
+		subject := opts.ContextDir
+		if len(opts.Tags) > 0 {
+			subject = opts.Tags[0]
+		}
+
+		log := dao.NewBuildLog()
+		ctx, cancel := context.WithCancel(context.Background())
+		app.SetFlashPending(fmt.Sprintf("building %s...", subject))
+		app.RunInBackground(func() {
+			defer cancel()
+			imageID, err := app.GetDocker().BuildImage(ctx, opts, log)
+			app.GetTviewApp().QueueUpdateDraw(func() {
+				if errors.Is(err, dao.ErrBuildCancelled) {
+					app.SetFlashError(fmt.Sprintf("build of %s cancelled", subject))
+					return
+				}
+				if err != nil {
+					app.SetFlashError(fmt.Sprintf("Build failed: %v", err))
+					return
+				}
+				app.SetFlashSuccess(fmt.Sprintf("built %s (%.12s)", subject, imageID))
+				app.ScheduleViewHighlight(styles.TitleImages, func(res dao.Resource) bool {
+					return imageID != "" && res.GetID() == imageID
+				}, styles.ColorStatusGreen, styles.ColorBlack, 2*time.Second)
+				app.RefreshCurrentView()
+			})
+		})
+		// Closing the build output stops the build
+		inspector := inspect.NewBuildInspector(subject, log)
+		inspector.OnClose = cancel
+		app.OpenInspector(inspector)
+	})
+}
+
+// SBOMAction lists the packages of the focused image, exportable as SPDX or CycloneDX
+func SBOMAction(app common.AppController, v *view.ResourceView) {
+	imgs := selectedImages(v)
+	if len(imgs) == 0 {
+		return
+	}
+	img := imgs[0]
+	target := img.ID
+	if hasTag(img) {
+		target = img.RepoTag
+	}
+
+	var sbom *dao.SBOM
+	inspector := inspect.NewTableInspector("SBOM image", target, []string{"NAME", "VERSION", "TYPE", "LICENSES"}, "Generating SBOM (syft may take a while on first run)...")
+	export := func(format string) {
+		if sbom == nil {
+			return
+		}
+		path, err := sbom.Export(format, config.ConfigDir())
+		if err != nil {
+			app.AppendFlashError(fmt.Sprintf("export failed: %v", err))
+			return
+		}
+		app.AppendFlashSuccess(fmt.Sprintf("SBOM exported to %s", daoCommon.ShortenPath(path)), 10*time.Second)
+	}
+	inspector.Shortcuts = []string{
+		common.FormatSCHeader("x", "Export SPDX"),
+		common.FormatSCHeader("shift-x", "Export CycloneDX"),
+	}
+	inspector.KeyHandler = func(event *tcell.EventKey) bool {
+		switch event.Rune() {
+		case 'x':
+			export(dao.SBOMFormatSPDX)
+			return true
+		case 'X':
+			export(dao.SBOMFormatCycloneDX)
+			return true
+		}
+		return false
+	}
+	app.OpenInspector(inspector)
+
+	cfg := app.GetConfig().D4S.SBOM
+	opts := dao.SBOMOptions{Mode: cfg.Mode, Image: cfg.Image, Socket: app.GetConfig().D4S.DockerSocket, NoRun: app.IsReadOnly()}
+	app.RunInBackground(func() {
+		result, err := app.GetDocker().GenerateSBOM(img.ID, target, opts)
+		app.GetTviewApp().QueueUpdateDraw(func() {
+			if err != nil {
+				inspector.SetMessage(fmt.Sprintf("Error: %v", err))
+				return
+			}
+			sbom = result
+			rows := make([][]string, len(result.Packages))
+			for i, p := range result.Packages {
+				rows[i] = []string{p.Name, p.Version, p.Type, p.Licenses}
+			}
+			inspector.Subject = fmt.Sprintf("%s@%s", target, result.Source)
+			inspector.SetRows(rows)
+		})
+	})
+}
+
 func DiveAction(app common.AppController, v *view.ResourceView) {
 	path, err := exec.LookPath("dive")
 	if err != nil {
-		app.AppendFlashError("dive command not found in PATH")
+		app.AppendFlashError("dive command not found in PATH (use shift-l for the built-in layer view)")
 		return
 	}
 
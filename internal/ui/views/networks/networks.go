@@ -61,11 +61,30 @@ func Inspect(app common.AppController, id string) {
 	})
 }
 
+// Topology maps every network with its containers, to see at a glance which
+// containers share a network and which ones bridge several of them
+func Topology(app common.AppController) {
+	inspector := inspect.NewTopologyInspector("networks")
+	app.OpenInspector(inspector)
+
+	app.RunInBackground(func() {
+		networks, err := app.GetDocker().NetworkTopology()
+		app.GetTviewApp().QueueUpdateDraw(func() {
+			if err != nil {
+				inspector.Viewer.Update(fmt.Sprintf("Error: %v", err), "text")
+				return
+			}
+			inspector.Viewer.Update(inspect.RenderTopology(networks), "text")
+		})
+	})
+}
+
 func GetShortcuts() []string {
 	return []string{
 		common.FormatSCHeader("d", "Describe"),
 		common.FormatSCHeader("enter", "Containers"),
 		common.FormatSCHeader("a", "Add"),
+		common.FormatSCHeader("t", "Topology"),
 		common.FormatSCHeader("shift-p", "Prune"),
 		common.FormatSCHeader("ctrl-d", "Delete"),
 	}
@@ -105,6 +124,9 @@ func InputHandler(v *view.ResourceView, event *tcell.EventKey) *tcell.EventKey {
 	case 'a':
 		Create(app)
 		return nil
+	case 't':
+		Topology(app)
+		return nil
 	case 'P':
 		PruneAction(app)
 		return nil
@@ -168,20 +190,26 @@ func Remove(id string, force bool, app common.AppController) error {
 }
 
 func Create(app common.AppController) {
-	dialogs.ShowInput(app, "Create Network", "Network Name: ", "", func(text string) {
-		app.SetFlashPending(fmt.Sprintf("creating network %s...", text))
+	if app.IsReadOnly() {
+		app.AppendFlashError("read-only mode: modifications are disabled")
+		return
+	}
+
+	dialogs.ShowNetworkForm(app, dao.NetworkSpec{Driver: "bridge"}, func(spec dao.NetworkSpec) {
+		name := spec.Name
+		app.SetFlashPending(fmt.Sprintf("creating network %s...", name))
 		app.RunInBackground(func() {
-			err := app.GetDocker().CreateNetwork(text)
+			err := app.GetDocker().CreateNetwork(spec)
 			app.GetTviewApp().QueueUpdateDraw(func() {
 				if err != nil {
 					app.SetFlashError(fmt.Sprintf("%v", err))
 				} else {
-					app.SetFlashSuccess(fmt.Sprintf("network %s created", text))
+					app.SetFlashSuccess(fmt.Sprintf("network %s created", name))
 					
 					// Highlight and Select the new resource
 					app.ScheduleViewHighlight(styles.TitleNetworks, func(res dao.Resource) bool {
 						net, ok := res.(dao.Network)
-						return ok && net.Name == text
+						return ok && net.Name == name
 					}, styles.ColorStatusGreen, styles.ColorStatusGreen, 2*time.Second)
 
 					app.RefreshCurrentView()
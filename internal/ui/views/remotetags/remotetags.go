@@ -0,0 +1,125 @@
+package remotetags
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jr-k/d4s/internal/dao"
+	daoCommon "github.com/jr-k/d4s/internal/dao/common"
+	"github.com/jr-k/d4s/internal/ui/common"
+	"github.com/jr-k/d4s/internal/ui/components/inspect"
+	"github.com/jr-k/d4s/internal/ui/components/view"
+	"github.com/jr-k/d4s/internal/ui/styles"
+)
+
+var Headers = []string{"TAG", "DIGEST", "SIZE", "PUSHED", "PLATFORMS"}
+
+// Set by the rescan key, the next fetch bypasses the registry cache
+var forceRefresh atomic.Bool
+
+func Fetch(app common.AppController, v *view.ResourceView) ([]dao.Resource, error) {
+	scope := app.GetActiveScope()
+	// Scope set by the Images view (shift-t) with the repository name
+	if scope == nil || scope.Type != "repository" {
+		return nil, fmt.Errorf("select an image and press shift-t in Images to browse its registry tags")
+	}
+	return app.GetDocker().ListRemoteTags(scope.Value, forceRefresh.Swap(false))
+}
+
+func GetShortcuts() []string {
+	return []string{
+		common.FormatSCHeader("enter", "Pull"),
+		common.FormatSCHeader("d", "Describe"),
+		common.FormatSCHeader("r", "Refresh"),
+	}
+}
+
+func InputHandler(v *view.ResourceView, event *tcell.EventKey) *tcell.EventKey {
+	app := v.App
+
+	if event.Key() == tcell.KeyEnter {
+		PullAction(app, v)
+		return nil
+	}
+
+	switch event.Rune() {
+	case 'd':
+		Describe(app, v)
+		return nil
+	case 'r':
+		forceRefresh.Store(true)
+		app.SetFlashPending("querying registry...")
+		app.RefreshCurrentView()
+		return nil
+	}
+
+	return event
+}
+
+func selectedTag(v *view.ResourceView) (dao.RemoteTag, bool) {
+	row, _ := v.Table.GetSelection()
+	if row <= 0 || row > len(v.Data) {
+		return dao.RemoteTag{}, false
+	}
+	tag, ok := v.Data[row-1].(dao.RemoteTag)
+	return tag, ok
+}
+
+func Describe(app common.AppController, v *view.ResourceView) {
+	tag, ok := selectedTag(v)
+	if !ok {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("reference: %s\n", tag.Ref()))
+	sb.WriteString(fmt.Sprintf("digest: %s\n", tag.Digest))
+	if tag.Size > 0 {
+		sb.WriteString(fmt.Sprintf("size: %s\n", daoCommon.FormatBytes(tag.Size)))
+	}
+	if !tag.Pushed.IsZero() {
+		sb.WriteString(fmt.Sprintf("pushed: %s\n", tag.Pushed.Local().Format("2006-01-02 15:04:05")))
+	}
+	if len(tag.Platforms) > 0 {
+		sb.WriteString("platforms:\n")
+		for _, p := range tag.Platforms {
+			sb.WriteString(fmt.Sprintf("  - %s\n", p))
+		}
+	}
+	app.OpenInspector(inspect.NewTextInspector("Describe tag", tag.Ref(), sb.String(), "yaml"))
+}
+
+// PullAction pulls the focused tag and follows its progress
+func PullAction(app common.AppController, v *view.ResourceView) {
+	if app.IsReadOnly() {
+		app.AppendFlashError("read-only mode: modifications are disabled")
+		return
+	}
+
+	tag, ok := selectedTag(v)
+	if !ok {
+		return
+	}
+
+	ref := tag.Ref()
+	progress := app.GetDocker().StartPullProgress(ref)
+	app.SetFlashPending(fmt.Sprintf("pulling %s...", ref))
+	app.RunInBackground(func() {
+		err := app.GetDocker().PullImage(ref)
+		app.GetTviewApp().QueueUpdateDraw(func() {
+			if err != nil {
+				app.SetFlashError(fmt.Sprintf("Pull failed: %v", err))
+				return
+			}
+			app.SetFlashSuccess(fmt.Sprintf("pulled %s", ref))
+			app.ScheduleViewHighlight(styles.TitleImages, func(res dao.Resource) bool {
+				img, ok := res.(dao.Image)
+				return ok && img.RepoTag == ref
+			}, styles.ColorStatusGreen, styles.ColorBlack, 2*time.Second)
+		})
+	})
+	app.OpenInspector(inspect.NewProgressInspector("Pull image", ref, progress))
+}
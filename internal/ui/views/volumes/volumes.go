@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
 	"syscall"
@@ -21,7 +22,9 @@ import (
 	"github.com/jr-k/d4s/internal/ui/styles"
 )
 
-var Headers = []string{"NAME", "DRIVER", "SCOPE", "USED BY", "MOUNTPOINT", "CREATED", "ANON"}
+var reMountPassword = regexp.MustCompile(`\b(password|pass)=[^,"]*`)
+
+var Headers = []string{"NAME", "DRIVER", "SCOPE", "SIZE", "REFCOUNT", "USED BY", "MOUNTPOINT", "CREATED", "ANON"}
 
 func Fetch(app common.AppController, v *view.ResourceView) ([]dao.Resource, error) {
 	scope := app.GetActiveScope()
@@ -39,6 +42,11 @@ func Fetch(app common.AppController, v *view.ResourceView) ([]dao.Resource, erro
 func GetShortcuts() []string {
 	return []string{
 		common.FormatSCHeader("s", "Shell"),
+		common.FormatSCHeader("b", "Browse Files"),
+		common.FormatSCHeader("y", "Copy Files"),
+		common.FormatSCHeader("shift-b", "Backup"),
+		common.FormatSCHeader("shift-r", "Restore"),
+		common.FormatSCHeader("shift-d", "Clone"),
 		common.FormatSCHeader("d", "Describe"),
 		common.FormatSCHeader("o", "Open"),
 		common.FormatSCHeader("a", "Add"),
@@ -64,9 +72,33 @@ func InputHandler(v *view.ResourceView, event *tcell.EventKey) *tcell.EventKey {
 	case 'o':
 		OpenAction(app, v)
 		return nil
+	case 'y':
+		if id, err := v.GetSelectedID(); err == nil {
+			CopyAction(app, id)
+		}
+		return nil
+	case 'b':
+		if id, err := v.GetSelectedID(); err == nil {
+			BrowseFiles(app, id)
+		}
+		return nil
 	case 'a':
 		Create(app)
 		return nil
+	case 'B':
+		if id, err := v.GetSelectedID(); err == nil {
+			BackupAction(app, id)
+		}
+		return nil
+	case 'R':
+		id, _ := v.GetSelectedID()
+		RestoreAction(app, id)
+		return nil
+	case 'D':
+		if id, err := v.GetSelectedID(); err == nil {
+			CloneAction(app, id)
+		}
+		return nil
 	case 'P':
 		PruneAction(app)
 		return nil
@@ -122,18 +154,36 @@ func Remove(id string, force bool, app common.AppController) error {
 }
 
 func Create(app common.AppController) {
-	dialogs.ShowInput(app, "Create Volume", "Volume Name: ", "", func(text string) {
-		app.SetFlashPending(fmt.Sprintf("creating volume %s...", text))
+	if app.IsReadOnly() {
+		app.AppendFlashError("read-only mode: modifications are disabled")
+		return
+	}
+
+	dialogs.ShowVolumeForm(app, dao.VolumeSpec{}, func(spec dao.VolumeSpec, testMount bool) {
+		label := spec.Name
+		if label == "" {
+			label = "volume"
+		}
+		app.SetFlashPending(fmt.Sprintf("creating volume %s...", label))
 		app.RunInBackground(func() {
-			err := app.GetDocker().CreateVolume(text)
+			docker := app.GetDocker()
+			name, err := docker.CreateVolume(spec)
+			if err == nil && testMount {
+				if err = docker.TestVolumeMount(name, app.GetConfig().D4S.ShellPod.Image); err != nil {
+					// A volume that can't be mounted is useless, don't leave it behind
+					// (Create refuses existing names, so this one is ours)
+					docker.RemoveVolume(name, false)
+					err = fmt.Errorf("mount test failed, volume removed: %v", err)
+				}
+			}
 			app.GetTviewApp().QueueUpdateDraw(func() {
 				if err != nil {
 					app.SetFlashError(fmt.Sprintf("%v", err))
 				} else {
-					app.SetFlashSuccess(fmt.Sprintf("volume %s created", text))
+					app.SetFlashSuccess(fmt.Sprintf("volume %s created", name))
 					app.ScheduleViewHighlight(styles.TitleVolumes, func(res dao.Resource) bool {
 						vol, ok := res.(dao.Volume)
-						return ok && vol.Name == text
+						return ok && vol.Name == name
 					}, styles.ColorStatusGreen, styles.ColorBlack, 2*time.Second)
 					app.RefreshCurrentView()
 				}
@@ -248,6 +298,9 @@ func Inspect(app common.AppController, id string) {
 			}
 		}
 
+		// Driver options (o=...) may hold CIFS credentials
+		content = reMountPassword.ReplaceAllString(content, "${1}=***")
+
 		app.GetTviewApp().QueueUpdateDraw(func() {
 			inspector.Subject = resolvedSubject
 			inspector.Viewer.Update(content, "json")
@@ -256,6 +309,199 @@ func Inspect(app common.AppController, id string) {
 	})
 }
 
+// BrowseFiles opens the file browser on a volume, mounted read-only in an
+// idle shell-pod container listed through the API (works over SSH)
+func BrowseFiles(app common.AppController, name string) {
+	app.SetFlashPending(fmt.Sprintf("mounting volume %s...", name))
+	app.RunInBackground(func() {
+		docker := app.GetDocker()
+		helperID, err := docker.StartVolumeHelper(name, app.GetConfig().D4S.ShellPod.Image)
+		app.GetTviewApp().QueueUpdateDraw(func() {
+			if err != nil {
+				app.SetFlashError(fmt.Sprintf("%v", err))
+				return
+			}
+			app.SetFlashSuccess(fmt.Sprintf("volume %s mounted", name))
+			browser := inspect.NewFileBrowser("Files volume", name, helperID, dao.VolumeHelperMountPoint)
+			browser.OnClose = func() {
+				docker.RemoveVolumeHelper(helperID)
+			}
+			app.OpenInspector(browser)
+		})
+	})
+}
+
+// CopyAction uploads or downloads files between this machine and a volume,
+// mounted in a stopped shell-pod container for the transfer
+func CopyAction(app common.AppController, name string) {
+	dialogs.ShowCopyForm(app, name, func(upload bool, src, dst string) {
+		if upload && app.IsReadOnly() {
+			app.AppendFlashError("read-only mode: modifications are disabled")
+			return
+		}
+
+		action := "Download volume"
+		if upload {
+			action = "Upload volume"
+		}
+		progress := dao.NewCopyProgress(src, dst)
+		shellImage := app.GetConfig().D4S.ShellPod.Image
+		app.RunInBackground(func() {
+			var err error
+			if upload {
+				err = app.GetDocker().UploadToVolume(name, shellImage, src, dst, progress)
+			} else {
+				err = app.GetDocker().DownloadFromVolume(name, shellImage, src, dst, progress)
+			}
+			app.GetTviewApp().QueueUpdateDraw(func() {
+				if err != nil {
+					app.SetFlashError(fmt.Sprintf("%v", err))
+				} else {
+					app.SetFlashSuccess(fmt.Sprintf("copied %s to %s", src, dst))
+				}
+			})
+		})
+		app.OpenInspector(inspect.NewCopyInspector(action, name, progress))
+	})
+}
+
+// BackupAction streams the content of a volume to a local .tar.gz archive
+func BackupAction(app common.AppController, name string) {
+	cwd, _ := os.Getwd()
+	defaultPath := filepath.Join(cwd, fmt.Sprintf("%s-%s.tar.gz", name, time.Now().Format("20060102-150405")))
+
+	dialogs.ShowInput(app, "Backup Volume", "Archive: ", daoCommon.ShortenPath(defaultPath), func(text string) {
+		file := daoCommon.ExpandPath(strings.TrimSpace(text))
+		if file == "" {
+			return
+		}
+		if _, err := os.Stat(file); err == nil {
+			app.SetFlashError(fmt.Sprintf("%s already exists", text))
+			return
+		}
+
+		progress := dao.NewCopyProgress(name, file)
+		shellImage := app.GetConfig().D4S.ShellPod.Image
+		app.RunInBackground(func() {
+			err := app.GetDocker().BackupVolume(name, shellImage, file, progress)
+			app.GetTviewApp().QueueUpdateDraw(func() {
+				if err != nil {
+					app.SetFlashError(fmt.Sprintf("backup failed: %v", err))
+				} else {
+					app.SetFlashSuccess(fmt.Sprintf("volume %s saved to %s", name, daoCommon.ShortenPath(file)))
+				}
+			})
+		})
+		app.OpenInspector(inspect.NewCopyInspector("Backup volume", name, progress))
+	})
+}
+
+// RestoreAction replaces the content of a volume (new or existing) with a
+// local .tar.gz archive, confirming before overwriting an existing volume
+func RestoreAction(app common.AppController, selected string) {
+	if app.IsReadOnly() {
+		app.AppendFlashError("read-only mode: modifications are disabled")
+		return
+	}
+
+	fields := []dialogs.FormField{
+		{Name: "file", Label: "Archive", Type: dialogs.FieldTypeInput, Placeholder: "~/backup.tar.gz"},
+		{Name: "volume", Label: "Volume", Type: dialogs.FieldTypeInput, Default: selected, Placeholder: "new or existing volume"},
+	}
+	description := "Restores a .tar.gz or .tar archive, checked first. An existing volume is emptied, a missing one is created."
+	dialogs.ShowFormWithNote(app, "Restore Volume", description, fields, func(result dialogs.FormResult) {
+		file := daoCommon.ExpandPath(strings.TrimSpace(result["file"]))
+		name := strings.TrimSpace(result["volume"])
+		if file == "" || name == "" {
+			app.SetFlashError("archive and volume are required")
+			return
+		}
+		if _, err := os.Stat(file); err != nil {
+			app.SetFlashError(fmt.Sprintf("%v", err))
+			return
+		}
+
+		restore := func() {
+			progress := dao.NewCopyProgress(file, name)
+			shellImage := app.GetConfig().D4S.ShellPod.Image
+			app.RunInBackground(func() {
+				err := app.GetDocker().RestoreVolume(name, shellImage, file, progress)
+				app.GetTviewApp().QueueUpdateDraw(func() {
+					if err != nil {
+						app.SetFlashError(fmt.Sprintf("restore failed: %v", err))
+						return
+					}
+					app.SetFlashSuccess(fmt.Sprintf("volume %s restored", name))
+					app.ScheduleViewHighlight(styles.TitleVolumes, func(res dao.Resource) bool {
+						vol, ok := res.(dao.Volume)
+						return ok && vol.Name == name
+					}, styles.ColorStatusGreen, styles.ColorBlack, 2*time.Second)
+					app.RefreshCurrentView()
+				})
+			})
+			app.OpenInspector(inspect.NewCopyInspector("Restore volume", name, progress))
+		}
+
+		app.SetFlashPending(fmt.Sprintf("checking volume %s...", name))
+		app.RunInBackground(func() {
+			exists := app.GetDocker().VolumeExists(name)
+			var users []string
+			if exists {
+				users, _ = app.GetDocker().VolumeRunningContainers(name)
+			}
+			app.GetTviewApp().QueueUpdateDraw(func() {
+				if !exists {
+					restore()
+					return
+				}
+				label := name
+				if len(users) > 0 {
+					// Their files change underneath them
+					label = fmt.Sprintf("%s (used by running %s)", name, strings.Join(users, ", "))
+				}
+				dialogs.ShowConfirmation(app, "OVERWRITE", label, func(force bool) {
+					restore()
+				})
+			})
+		})
+	})
+}
+
+// CloneAction creates a copy of a volume, settings and content
+func CloneAction(app common.AppController, name string) {
+	if app.IsReadOnly() {
+		app.AppendFlashError("read-only mode: modifications are disabled")
+		return
+	}
+
+	dialogs.ShowInput(app, "Clone Volume", "New Volume: ", name+"-copy", func(text string) {
+		target := strings.TrimSpace(text)
+		if target == "" {
+			return
+		}
+		app.SetFlashPending(fmt.Sprintf("cloning volume %s to %s...", name, target))
+		app.RunInBackground(func() {
+			plain, err := app.GetDocker().CloneVolume(name, target, app.GetConfig().D4S.ShellPod.Image)
+			app.GetTviewApp().QueueUpdateDraw(func() {
+				if err != nil {
+					app.SetFlashError(fmt.Sprintf("clone failed: %v", err))
+					return
+				}
+				if plain {
+					app.SetFlashSuccess(fmt.Sprintf("volume %s cloned to %s (plain local volume, the device options were not copied)", name, target))
+				} else {
+					app.SetFlashSuccess(fmt.Sprintf("volume %s cloned to %s", name, target))
+				}
+				app.ScheduleViewHighlight(styles.TitleVolumes, func(res dao.Resource) bool {
+					vol, ok := res.(dao.Volume)
+					return ok && vol.Name == target
+				}, styles.ColorStatusGreen, styles.ColorBlack, 2*time.Second)
+				app.RefreshCurrentView()
+			})
+		})
+	})
+}
+
 func Shell(app common.AppController, id string) {
 	app.StopAutoRefresh()
 	app.SetPaused(true)